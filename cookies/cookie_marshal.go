@@ -0,0 +1,97 @@
+package cookies // import "go.gideaworx.io/go-encoding/cookies"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// CookieMarshaler lets implementations convert themselves into a set of cookies.
+type CookieMarshaler interface {
+	MarshalCookies() ([]*http.Cookie, error)
+}
+
+// MarshalCookies will take a struct (or a non-nil pointer to one, or a CookieMarshaler) and serialize each exported
+// field into its own cookie, in field order. The cookie name and attributes are controlled by the "cookie" struct tag,
+// whose name, omit (omitempty, omitzero and omitnil) and join options follow the "url" tag grammar of the urlvalues
+// package. The remaining options set the attributes of the cookie. For example, given the struct
+//
+//	type Preferences struct {
+//		Theme    string        `cookie:"theme,path=/,maxage=31536000,samesite=lax"`
+//		Session  string        `cookie:"sid,path=/,secure,httponly,samesite=strict,omitempty"`
+//		Pinned   []int         `cookie:"pinned,join='|'"`
+//		Idle     time.Duration `cookie:"idle" cookieformat:"int,s"`
+//	}
+//
+// the supported attribute options are path=..., domain=..., maxage=..., secure, httponly, partitioned and
+// samesite=lax|strict|none|default. Values are converted exactly as urlvalues.MarshalURLValues converts them (the
+// "cookieformat" tag accepts the same formats as "urlformat"), slices are joined with "," unless a join option is
+// given, and the result is query-escaped so it is always a valid cookie value.
+func MarshalCookies(a any) ([]*http.Cookie, error) {
+	if m, ok := a.(CookieMarshaler); ok {
+		return m.MarshalCookies()
+	}
+
+	if a == nil {
+		return nil, errors.New("value cannot be nil")
+	}
+
+	v := reflect.ValueOf(a)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, errors.New("value cannot be nil")
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, errors.New("argument must be a struct or non-nil pointer to a struct")
+	}
+
+	var cookies []*http.Cookie
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, err := parseCookieTag(sf)
+		if err != nil {
+			if errors.Is(err, urlvalues.ErrSkip) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		fv := v.Field(i)
+		if tag.Omits(fv) {
+			continue
+		}
+
+		valueStrings, err := urlvalues.FormatValues(fv, sf.Tag.Get("cookieformat"))
+		if err != nil {
+			return nil, err
+		}
+
+		if valueStrings == nil {
+			continue
+		}
+
+		c := tag.cookie(url.QueryEscape(strings.Join(valueStrings, tag.Join)))
+		if err := c.Valid(); err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+
+		cookies = append(cookies, c)
+	}
+
+	return cookies, nil
+}
@@ -0,0 +1,106 @@
+package cookies // import "go.gideaworx.io/go-encoding/cookies"
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+const defaultJoin = ","
+
+type cookieTag struct {
+	urlvalues.Tag
+	path        string
+	domain      string
+	maxAge      int
+	secure      bool
+	httpOnly    bool
+	partitioned bool
+	sameSite    http.SameSite
+}
+
+// parseCookieTag parses the cookie tag of sf. The name, omit and join options follow the "url" tag grammar; the
+// remaining options describe the attributes of the cookie.
+func parseCookieTag(sf reflect.StructField) (*cookieTag, error) {
+	t := &cookieTag{Tag: urlvalues.Tag{Name: sf.Name}}
+
+	tagString, ok := sf.Tag.Lookup("cookie")
+	if ok {
+		tag, err := urlvalues.ParseTag(tagString)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Tag = tag
+		if t.Name == "" {
+			t.Name = sf.Name
+		}
+
+		if err := t.parseAttributes(strings.Split(tagString, ",")[1:]); err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+	}
+
+	if t.Join == "" {
+		t.Join = defaultJoin
+	}
+
+	return t, nil
+}
+
+func (t *cookieTag) parseAttributes(options []string) error {
+	for _, option := range options {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch strings.ToLower(key) {
+		case "path":
+			t.path = value
+		case "domain":
+			t.domain = value
+		case "maxage":
+			maxAge, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid maxage %q: %w", value, err)
+			}
+			t.maxAge = maxAge
+		case "secure":
+			t.secure = true
+		case "httponly":
+			t.httpOnly = true
+		case "partitioned":
+			t.partitioned = true
+		case "samesite":
+			switch strings.ToLower(value) {
+			case "lax":
+				t.sameSite = http.SameSiteLaxMode
+			case "strict":
+				t.sameSite = http.SameSiteStrictMode
+			case "none":
+				t.sameSite = http.SameSiteNoneMode
+			case "", "default":
+				t.sameSite = http.SameSiteDefaultMode
+			default:
+				return fmt.Errorf("invalid samesite %q. only lax, strict, none, and default are supported", value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *cookieTag) cookie(value string) *http.Cookie {
+	return &http.Cookie{
+		Name:        t.Name,
+		Value:       value,
+		Path:        t.path,
+		Domain:      t.domain,
+		MaxAge:      t.maxAge,
+		Secure:      t.secure,
+		HttpOnly:    t.httpOnly,
+		Partitioned: t.partitioned,
+		SameSite:    t.sameSite,
+	}
+}
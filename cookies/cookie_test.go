@@ -0,0 +1,115 @@
+package cookies_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/cookies"
+)
+
+type preferences struct {
+	Theme   string        `cookie:"theme,path=/,maxage=3600,samesite=lax"`
+	Session string        `cookie:"sid,path=/app,domain=example.com,secure,httponly,samesite=strict,omitempty"`
+	Pinned  []int         `cookie:"pinned,join='|'"`
+	Tags    []string      `cookie:"tags"`
+	Idle    time.Duration `cookie:"idle" cookieformat:"int,s"`
+	Beta    *bool         `cookie:"beta"`
+	Skipped string        `cookie:"-"`
+}
+
+var _ = Describe("Cookies", func() {
+	p := preferences{
+		Theme:  "dark mode",
+		Pinned: []int{3, 1, 4},
+		Tags:   []string{"a b", "c;d"},
+		Idle:   90 * time.Second,
+	}
+
+	Describe("Marshaling", func() {
+		It("marshals a struct with cookie attributes", func() {
+			c, err := cookies.MarshalCookies(p)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(Equal([]*http.Cookie{
+				{Name: "theme", Value: "dark+mode", Path: "/", MaxAge: 3600, SameSite: http.SameSiteLaxMode},
+				{Name: "pinned", Value: "3%7C1%7C4"},
+				{Name: "tags", Value: "a+b%2Cc%3Bd"},
+				{Name: "idle", Value: "90"},
+			}))
+		})
+
+		It("applies the omit options the way urlvalues does", func() {
+			c, err := cookies.MarshalCookies(struct {
+				Since time.Time `cookie:"since,omitzero"`
+				Tags  []string  `cookie:"tags,omitempty"`
+				Count int       `cookie:"count"`
+			}{Since: time.Time{}.In(time.FixedZone("X", 3600)), Tags: []string{}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).To(Equal([]*http.Cookie{{Name: "count", Value: "0"}}))
+		})
+
+		It("sets every supported attribute", func() {
+			c, err := cookies.MarshalCookies(&preferences{Session: "s3cr3t"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c[1]).To(Equal(&http.Cookie{
+				Name:     "sid",
+				Value:    "s3cr3t",
+				Path:     "/app",
+				Domain:   "example.com",
+				Secure:   true,
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+			}))
+		})
+
+		It("fails on invalid attributes", func() {
+			_, err := cookies.MarshalCookies(struct {
+				A string `cookie:"a,samesite=sometimes"`
+			}{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("fails on invalid cookie names", func() {
+			_, err := cookies.MarshalCookies(struct {
+				A string `cookie:"a b"`
+			}{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Unmarshaling", func() {
+		It("round trips through an http request", func() {
+			marshaled, err := cookies.MarshalCookies(p)
+			Expect(err).NotTo(HaveOccurred())
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, c := range marshaled {
+				req.AddCookie(c)
+			}
+
+			var out preferences
+			Expect(cookies.UnmarshalCookies(req.Cookies(), &out)).To(Succeed())
+			Expect(out).To(Equal(p))
+		})
+
+		It("unmarshals pointers", func() {
+			var out preferences
+			Expect(cookies.UnmarshalCookies([]*http.Cookie{{Name: "beta", Value: "true"}}, &out)).To(Succeed())
+			Expect(out.Beta).NotTo(BeNil())
+			Expect(*out.Beta).To(BeTrue())
+		})
+
+		It("reports the cookie that failed to parse", func() {
+			var out preferences
+			err := cookies.UnmarshalCookies([]*http.Cookie{{Name: "idle", Value: "soon"}}, &out)
+			Expect(err).To(MatchError(ContainSubstring("idle")))
+		})
+
+		It("fails on a non-struct pointer", func() {
+			Expect(cookies.UnmarshalCookies(nil, preferences{})).To(HaveOccurred())
+			Expect(cookies.UnmarshalCookies(nil, nil)).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,103 @@
+package cookies // import "go.gideaworx.io/go-encoding/cookies"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// CookieUnmarshaler allows implementations to decode a set of cookies in a custom way
+type CookieUnmarshaler interface {
+	UnmarshalCookies([]*http.Cookie) error
+}
+
+// UnmarshalCookies will take a set of cookies, typically from (*http.Request).Cookies(), and deserialize them into the
+// struct pointed to by a, which must be a non-nil pointer to a struct or a CookieUnmarshaler. Each field is populated
+// from the first cookie whose name matches its "cookie" tag exactly; attribute options in the tag are ignored, since
+// browsers do not send them back. Values are query-unescaped, split on the tag's join option (or ",") for slice and
+// array fields, and converted with the same rules as urlvalues.UnmarshalURLValues.
+func UnmarshalCookies(cookies []*http.Cookie, a any) error {
+	if a == nil {
+		return errors.New("second argument must not be nil")
+	}
+
+	if um, ok := a.(CookieUnmarshaler); ok {
+		return um.UnmarshalCookies(cookies)
+	}
+
+	v := reflect.ValueOf(a)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("second argument must be a non-nil pointer to a struct")
+	}
+
+	byName := make(map[string]string, len(cookies))
+	for _, c := range cookies {
+		if c == nil {
+			continue
+		}
+
+		if _, ok := byName[c.Name]; !ok {
+			byName[c.Name] = c.Value
+		}
+	}
+
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, err := parseCookieTag(sf)
+		if err != nil {
+			if errors.Is(err, urlvalues.ErrSkip) {
+				continue
+			}
+
+			return err
+		}
+
+		raw, ok := byName[tag.Name]
+		if !ok {
+			continue
+		}
+
+		value, err := url.QueryUnescape(raw)
+		if err != nil {
+			return fmt.Errorf("cookie %s: %w", tag.Name, err)
+		}
+
+		elemType := sf.Type
+		if elemType.Kind() == reflect.Pointer {
+			elemType = elemType.Elem()
+		}
+
+		values := []string{value}
+		if urlvalues.IsList(elemType) {
+			if value == "" {
+				continue
+			}
+
+			values = strings.Split(value, tag.Join)
+		}
+
+		parsedValue, err := urlvalues.ParseValues(values, sf.Type, sf.Tag.Get("cookieformat"), "")
+		if err != nil {
+			return fmt.Errorf("cookie %s: %w", tag.Name, err)
+		}
+
+		if tag.OmitEmpty && (!parsedValue.IsValid() || parsedValue.IsZero()) {
+			continue
+		}
+
+		v.Field(i).Set(parsedValue)
+	}
+
+	return nil
+}
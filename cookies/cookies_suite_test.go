@@ -0,0 +1,13 @@
+package cookies_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCookies(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cookies Suite")
+}
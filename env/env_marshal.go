@@ -0,0 +1,104 @@
+package env // import "go.gideaworx.io/go-encoding/env"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// EnvMarshaler lets implementations convert themselves into KEY=VALUE lines.
+type EnvMarshaler interface {
+	MarshalEnv() ([]string, error)
+}
+
+// MarshalEnv serializes a struct (or a non-nil pointer to one, or an EnvMarshaler) into KEY=VALUE lines in field
+// order, suitable for os/exec.Cmd.Env or a .env file. It is the inverse of UnmarshalEnv: the same "env" and
+// "envformat" tags are honored, slices are joined with the join option (or ","), and nested structs are written
+// with their prefixes. Nil pointers, and fields left out by their omitempty, omitzero or omitnil option (see
+// urlvalues.Tag.Omits), are not written.
+func MarshalEnv(a any) ([]string, error) {
+	if m, ok := a.(EnvMarshaler); ok {
+		return m.MarshalEnv()
+	}
+
+	if a == nil {
+		return nil, errors.New("value cannot be nil")
+	}
+
+	v := reflect.ValueOf(a)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, errors.New("value cannot be nil")
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, errors.New("argument must be a struct or non-nil pointer to a struct")
+	}
+
+	var lines []string
+	if err := marshalStruct(&lines, "", v); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+func marshalStruct(lines *[]string, prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, err := parseEnvTag(sf)
+		if err != nil {
+			if errors.Is(err, urlvalues.ErrSkip) {
+				continue
+			}
+
+			return err
+		}
+
+		fv := v.Field(i)
+		if tag.Omits(fv) {
+			continue
+		}
+
+		if urlvalues.IsStructType(sf.Type) {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+
+				fv = fv.Elem()
+			}
+
+			if err := marshalStruct(lines, prefix+nestedPrefix(sf, tag), fv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		key := prefix + tag.Name
+		valueStrings, err := urlvalues.FormatValues(fv, sf.Tag.Get("envformat"))
+		if err != nil {
+			return fmt.Errorf("variable %s: %w", key, err)
+		}
+
+		if valueStrings == nil {
+			continue
+		}
+
+		*lines = append(*lines, key+"="+strings.Join(valueStrings, tag.Join))
+	}
+
+	return nil
+}
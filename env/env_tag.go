@@ -0,0 +1,45 @@
+package env // import "go.gideaworx.io/go-encoding/env"
+
+import (
+	"reflect"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+const defaultJoin = ","
+
+// parseEnvTag parses the env tag of sf. The tag follows the "url" tag grammar of the urlvalues package, including its
+// "required" and "default=..." options. Defaults that contain commas must be quoted the same way join strings are,
+// e.g. `env:"HOSTS,default='a,b'"`.
+func parseEnvTag(sf reflect.StructField) (urlvalues.Tag, error) {
+	tag := urlvalues.Tag{Name: sf.Name}
+
+	if tagString, ok := sf.Tag.Lookup("env"); ok {
+		var err error
+		if tag, err = urlvalues.ParseTag(tagString); err != nil {
+			return tag, err
+		}
+
+		if tag.Name == "" {
+			tag.Name = sf.Name
+		}
+	}
+
+	if tag.Join == "" {
+		tag.Join = defaultJoin
+	}
+
+	return tag, nil
+}
+
+// nestedPrefix returns the prefix applied to the variables of a nested struct field. Embedded structs are flattened
+// into their parent, while named fields add their tag name (or field name) and an underscore.
+func nestedPrefix(sf reflect.StructField, tag urlvalues.Tag) string {
+	if sf.Anonymous {
+		if _, ok := sf.Tag.Lookup("env"); !ok {
+			return ""
+		}
+	}
+
+	return tag.Name + "_"
+}
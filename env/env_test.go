@@ -0,0 +1,196 @@
+package env_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/env"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+type Common struct {
+	Debug bool `env:"DEBUG"`
+}
+
+type database struct {
+	Host string `env:"HOST,default=localhost"`
+	Port int    `env:"PORT,default=5432"`
+}
+
+// version is a struct type converted by a codec rather than read as a group of variables.
+type version struct {
+	Major, Minor int
+}
+
+func init() {
+	urlvalues.RegisterCodec(func(v version) (string, error) {
+		return fmt.Sprintf("%d.%d", v.Major, v.Minor), nil
+	}, func(s string) (version, error) {
+		var v version
+		_, err := fmt.Sscanf(s, "%d.%d", &v.Major, &v.Minor)
+		return v, err
+	})
+}
+
+type config struct {
+	Common
+	Name     string        `env:"NAME,required"`
+	Hosts    []string      `env:"HOSTS,default='a,b'"`
+	Ports    []int         `env:"PORTS,join=':'"`
+	Timeout  time.Duration `env:"TIMEOUT,default=5s"`
+	Interval time.Duration `env:"INTERVAL_MS" envformat:"int,ms"`
+	DB       database      `env:"DB"`
+	Cache    *database     `env:"CACHE"`
+	Ratio    *float64      `env:"RATIO"`
+	Skipped  string        `env:"-"`
+}
+
+var _ = Describe("Env", func() {
+	Describe("Unmarshaling", func() {
+		It("decodes environ-style input with defaults and prefixes", func() {
+			var c config
+			err := env.UnmarshalEnv([]string{
+				"NAME=svc",
+				"DEBUG=true",
+				"PORTS=80:443",
+				"INTERVAL_MS=250",
+				"DB_HOST=db.internal",
+				"RATIO=0.5",
+				"Skipped=nope",
+			}, &c)
+			Expect(err).NotTo(HaveOccurred())
+
+			ratio := 0.5
+			Expect(c).To(Equal(config{
+				Common:   Common{Debug: true},
+				Name:     "svc",
+				Hosts:    []string{"a", "b"},
+				Ports:    []int{80, 443},
+				Timeout:  5 * time.Second,
+				Interval: 250 * time.Millisecond,
+				DB:       database{Host: "db.internal", Port: 5432},
+				Ratio:    &ratio,
+			}))
+		})
+
+		It("allocates nested pointers only when one of their variables is set", func() {
+			var c config
+			Expect(env.UnmarshalEnv([]string{"NAME=svc", "CACHE_PORT=6379"}, &c)).To(Succeed())
+			Expect(c.Cache).To(Equal(&database{Host: "localhost", Port: 6379}))
+		})
+
+		It("uses an injected lookup function", func() {
+			lookup := func(key string) (string, bool) {
+				if key == "NAME" {
+					return "injected", true
+				}
+
+				return "", false
+			}
+
+			var c config
+			Expect(env.UnmarshalLookup(lookup, &c)).To(Succeed())
+			Expect(c.Name).To(Equal("injected"))
+		})
+
+		It("decodes struct types with a codec as single variables", func() {
+			var out struct {
+				Version  version  `env:"VERSION"`
+				Previous *version `env:"PREVIOUS"`
+			}
+
+			Expect(env.UnmarshalEnv([]string{"VERSION=1.2", "PREVIOUS=1.1"}, &out)).To(Succeed())
+			Expect(out.Version).To(Equal(version{1, 2}))
+			Expect(out.Previous).To(Equal(&version{1, 1}))
+
+			lines, err := env.MarshalEnv(out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lines).To(Equal([]string{"VERSION=1.2", "PREVIOUS=1.1"}))
+		})
+
+		It("decodes standard library value types", func() {
+			var out struct {
+				Addr  netip.Addr `env:"ADDR"`
+				URL   *url.URL   `env:"U"`
+				Total big.Int    `env:"N"`
+			}
+
+			Expect(env.UnmarshalEnv([]string{"ADDR=10.0.0.1", "U=http://x", "N=5"}, &out)).To(Succeed())
+			Expect(out.Addr).To(Equal(netip.MustParseAddr("10.0.0.1")))
+			Expect(out.URL.String()).To(Equal("http://x"))
+			Expect(out.Total.Int64()).To(BeEquivalentTo(5))
+
+			lines, err := env.MarshalEnv(&out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lines).To(Equal([]string{"ADDR=10.0.0.1", "U=http://x", "N=5"}))
+		})
+
+		It("fails when a required variable is missing", func() {
+			var c config
+			Expect(env.UnmarshalEnv(nil, &c)).To(MatchError(ContainSubstring("NAME")))
+		})
+
+		It("reports the variable that failed to parse", func() {
+			var c config
+			err := env.UnmarshalEnv([]string{"NAME=svc", "DB_PORT=http"}, &c)
+			Expect(err).To(MatchError(ContainSubstring("DB_PORT")))
+		})
+
+		It("fails on a non-struct pointer", func() {
+			Expect(env.UnmarshalEnv(nil, config{})).To(HaveOccurred())
+			Expect(env.UnmarshalLookup(nil, &config{})).To(HaveOccurred())
+		})
+	})
+
+	Describe("Marshaling", func() {
+		It("writes KEY=VALUE lines in field order", func() {
+			lines, err := env.MarshalEnv(&config{
+				Name:     "svc",
+				Hosts:    []string{"a", "b"},
+				Ports:    []int{80, 443},
+				Timeout:  time.Second,
+				Interval: 2 * time.Second,
+				DB:       database{Host: "db", Port: 1},
+				Cache:    &database{Host: "cache", Port: 2},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lines).To(Equal([]string{
+				"DEBUG=false",
+				"NAME=svc",
+				"HOSTS=a,b",
+				"PORTS=80:443",
+				"TIMEOUT=1s",
+				"INTERVAL_MS=2000",
+				"DB_HOST=db",
+				"DB_PORT=1",
+				"CACHE_HOST=cache",
+				"CACHE_PORT=2",
+			}))
+		})
+
+		It("applies the omit options the way urlvalues does", func() {
+			lines, err := env.MarshalEnv(struct {
+				Since time.Time `env:"SINCE,omitzero"`
+				Hosts []string  `env:"HOSTS,omitempty"`
+				Count int       `env:"COUNT"`
+			}{Since: time.Time{}.In(time.FixedZone("X", 3600)), Hosts: []string{}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lines).To(Equal([]string{"COUNT=0"}))
+		})
+
+		It("round trips", func() {
+			in := config{Name: "svc", Hosts: []string{"x"}, Ports: []int{1}, Timeout: time.Minute, DB: database{"h", 2}}
+			lines, err := env.MarshalEnv(in)
+			Expect(err).NotTo(HaveOccurred())
+
+			var out config
+			Expect(env.UnmarshalEnv(lines, &out)).To(Succeed())
+			Expect(out).To(Equal(in))
+		})
+	})
+})
@@ -0,0 +1,180 @@
+package env // import "go.gideaworx.io/go-encoding/env"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// LookupFunc retrieves the value of the environment variable named by key, reporting whether it was present.
+// os.LookupEnv is a LookupFunc.
+type LookupFunc func(key string) (string, bool)
+
+// EnvUnmarshaler allows implementations to decode environment variables in a custom way
+type EnvUnmarshaler interface {
+	UnmarshalEnv(LookupFunc) error
+}
+
+// Load decodes the current process environment into a. It is shorthand for UnmarshalLookup(os.LookupEnv, a).
+func Load(a any) error {
+	return UnmarshalLookup(os.LookupEnv, a)
+}
+
+// UnmarshalEnv decodes environ, a list of KEY=VALUE strings in the form returned by os.Environ, into a. If a key
+// appears more than once, the last value wins, as it does for the process environment.
+func UnmarshalEnv(environ []string, a any) error {
+	m := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			m[k] = v
+		}
+	}
+
+	return UnmarshalLookup(func(key string) (string, bool) {
+		v, ok := m[key]
+		return v, ok
+	}, a)
+}
+
+// UnmarshalLookup decodes the variables returned by lookup into the struct pointed to by a, which must be a non-nil
+// pointer to a struct or an EnvUnmarshaler. Variable names are controlled by the "env" struct tag, which follows the
+// "url" tag grammar of the urlvalues package, including its required and default options:
+//
+//	type Config struct {
+//		Port     int           `env:"PORT,default=8080"`
+//		Hosts    []string      `env:"HOSTS,required"`
+//		Timeout  time.Duration `env:"TIMEOUT,default=5s"`
+//		Interval time.Duration `env:"INTERVAL_MS" envformat:"int,ms"`
+//		DB       Database      `env:"DB"`
+//	}
+//
+// "default=..." supplies the value to parse when the variable is not set (quote it like a join string if it contains
+// commas) and "required" makes a missing variable with no default an error. Slices and arrays are split on the join
+// option, or "," if none is given. Fields whose type is a struct are decoded recursively, with their variable names
+// prefixed by the field's name and an underscore, so Database.Host above is read from DB_HOST, and embedded structs
+// are flattened without a prefix. time.Time and types with a codec (see urlvalues.HasCodec) are single variables.
+// Fields whose variables are not set are left untouched.
+func UnmarshalLookup(lookup LookupFunc, a any) error {
+	if lookup == nil {
+		return errors.New("lookup must not be nil")
+	}
+
+	if a == nil {
+		return errors.New("second argument must not be nil")
+	}
+
+	if um, ok := a.(EnvUnmarshaler); ok {
+		return um.UnmarshalEnv(lookup)
+	}
+
+	v := reflect.ValueOf(a)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("second argument must be a non-nil pointer to a struct")
+	}
+
+	_, err := unmarshalStruct(lookup, "", v.Elem())
+	return err
+}
+
+// unmarshalStruct decodes the fields of v, reporting whether any variable was found.
+func unmarshalStruct(lookup LookupFunc, prefix string, v reflect.Value) (bool, error) {
+	found := false
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, err := parseEnvTag(sf)
+		if err != nil {
+			if errors.Is(err, urlvalues.ErrSkip) {
+				continue
+			}
+
+			return found, err
+		}
+
+		fv := v.Field(i)
+		if urlvalues.IsStructType(sf.Type) {
+			nestedFound, err := unmarshalNested(lookup, prefix+nestedPrefix(sf, tag), fv)
+			if err != nil {
+				return found, err
+			}
+
+			found = found || nestedFound
+			continue
+		}
+
+		key := prefix + tag.Name
+		value, ok := lookup(key)
+		if !ok {
+			if tag.Required && !tag.HasDefault {
+				return found, fmt.Errorf("required variable %s is not set", key)
+			}
+
+			if !tag.HasDefault {
+				continue
+			}
+
+			value = tag.Default
+		} else {
+			found = true
+		}
+
+		elemType := sf.Type
+		if elemType.Kind() == reflect.Pointer {
+			elemType = elemType.Elem()
+		}
+
+		values := []string{value}
+		if urlvalues.IsList(elemType) {
+			if value == "" {
+				continue
+			}
+
+			values = strings.Split(value, tag.Join)
+		}
+
+		parsedValue, err := urlvalues.ParseValues(values, sf.Type, sf.Tag.Get("envformat"), "")
+		if err != nil {
+			return found, fmt.Errorf("variable %s: %w", key, err)
+		}
+
+		if tag.OmitEmpty && (!parsedValue.IsValid() || parsedValue.IsZero()) {
+			continue
+		}
+
+		fv.Set(parsedValue)
+	}
+
+	return found, nil
+}
+
+func unmarshalNested(lookup LookupFunc, prefix string, fv reflect.Value) (bool, error) {
+	if fv.Kind() != reflect.Pointer {
+		return unmarshalStruct(lookup, prefix, fv)
+	}
+
+	if !fv.IsNil() {
+		return unmarshalStruct(lookup, prefix, fv.Elem())
+	}
+
+	// only allocate nil struct pointers if one of their variables is actually present
+	nested := reflect.New(fv.Type().Elem())
+	found, err := unmarshalStruct(lookup, prefix, nested.Elem())
+	if err != nil {
+		return found, err
+	}
+
+	if found {
+		fv.Set(nested)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,181 @@
+package flags // import "go.gideaworx.io/go-encoding/flags"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// Register defines a flag on fs for every exported field of the struct pointed to by a. Flag names are controlled by
+// the "flag" struct tag, which follows the "url" tag grammar of the urlvalues package, and the help text comes from
+// the "usage" tag. For example
+//
+//	type Options struct {
+//		Addr    string        `flag:"addr" usage:"address to listen on"`
+//		Timeout time.Duration `flag:"timeout" usage:"request timeout"`
+//		Poll    time.Duration `flag:"poll-ms" flagformat:"int,ms" usage:"poll interval in milliseconds"`
+//		Tags    []string      `flag:"tag" usage:"tag to apply (repeatable)"`
+//		Hosts   []string      `flag:"hosts,join=','" usage:"comma separated hosts"`
+//		Verbose bool          `flag:"v" usage:"verbose output"`
+//		DB      Database      `flag:"db"`
+//	}
+//
+// The current value of each field is its default. Values are parsed with the same rules as
+// urlvalues.UnmarshalURLValues, and the "flagformat" tag accepts the same formats as "urlformat". Slice fields may be
+// repeated on the command line; the first occurrence replaces the default and later ones append to it. If the tag has
+// a join option, each occurrence is also split on it. Bool fields may be given without a value (-v). Fields whose type
+// is a struct define their flags with the field's name and a dot as a prefix (-db.host), and embedded structs are
+// flattened without a prefix. time.Time and types with a codec (see urlvalues.HasCodec), such as *url.URL, are single
+// flags rather than structs.
+func Register(fs *flag.FlagSet, a any) error {
+	if fs == nil {
+		return errors.New("flag set must not be nil")
+	}
+
+	if a == nil {
+		return errors.New("second argument must not be nil")
+	}
+
+	v := reflect.ValueOf(a)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("second argument must be a non-nil pointer to a struct")
+	}
+
+	return registerStruct(fs, "", v.Elem())
+}
+
+func registerStruct(fs *flag.FlagSet, prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := urlvalues.Tag{Name: sf.Name}
+		tagString, hasTag := sf.Tag.Lookup("flag")
+		if hasTag {
+			var err error
+			if tag, err = urlvalues.ParseTag(tagString); err != nil {
+				if errors.Is(err, urlvalues.ErrSkip) {
+					continue
+				}
+
+				return err
+			}
+
+			if tag.Name == "" {
+				tag.Name = sf.Name
+			}
+		}
+
+		fv := v.Field(i)
+		if urlvalues.IsStructType(sf.Type) {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					fv.Set(reflect.New(sf.Type.Elem()))
+				}
+
+				fv = fv.Elem()
+			}
+
+			nestedPrefix := prefix + tag.Name + "."
+			if sf.Anonymous && !hasTag {
+				nestedPrefix = prefix
+			}
+
+			if err := registerStruct(fs, nestedPrefix, fv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		name := prefix + tag.Name
+		if fs.Lookup(name) != nil {
+			return fmt.Errorf("flag %s is defined more than once", name)
+		}
+
+		fs.Var(&fieldValue{
+			v:      fv,
+			format: sf.Tag.Get("flagformat"),
+			join:   tag.Join,
+		}, name, sf.Tag.Get("usage"))
+	}
+
+	return nil
+}
+
+// fieldValue is a flag.Value backed by a struct field.
+type fieldValue struct {
+	v      reflect.Value
+	format string
+	join   string
+	seen   []string
+}
+
+func (f *fieldValue) String() string {
+	// the flag package calls String on a zero fieldValue to detect default values
+	if f == nil || !f.v.IsValid() {
+		return ""
+	}
+
+	join := f.join
+	if join == "" {
+		join = ","
+	}
+
+	strs, _ := urlvalues.FormatValues(f.v, f.format)
+	return strings.Join(strs, join)
+}
+
+func (f *fieldValue) Set(s string) error {
+	if !f.isIterable() {
+		parsed, err := urlvalues.ParseValues([]string{s}, f.v.Type(), f.format, "")
+		if err != nil {
+			return err
+		}
+
+		f.v.Set(parsed)
+		return nil
+	}
+
+	previous := len(f.seen)
+	if f.join != "" {
+		f.seen = append(f.seen, strings.Split(s, f.join)...)
+	} else {
+		f.seen = append(f.seen, s)
+	}
+
+	parsed, err := urlvalues.ParseValues(f.seen, f.v.Type(), f.format, "")
+	if err != nil {
+		f.seen = f.seen[:previous]
+		return err
+	}
+
+	f.v.Set(parsed)
+	return nil
+}
+
+// IsBoolFlag lets bool fields be set without a value, e.g. -v instead of -v=true.
+func (f *fieldValue) IsBoolFlag() bool {
+	t := f.v.Type()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Bool
+}
+
+func (f *fieldValue) isIterable() bool {
+	t := f.v.Type()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return urlvalues.IsList(t)
+}
@@ -0,0 +1,162 @@
+package flags_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/flags"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+type Shared struct {
+	Verbose bool `flag:"v" usage:"verbose output"`
+}
+
+type database struct {
+	Host string `flag:"host" usage:"database host"`
+	Port int    `flag:"port"`
+}
+
+// version is a struct type converted by a codec rather than registered as a group of flags.
+type version struct {
+	Major, Minor int
+}
+
+func init() {
+	urlvalues.RegisterCodec(func(v version) (string, error) {
+		return fmt.Sprintf("%d.%d", v.Major, v.Minor), nil
+	}, func(s string) (version, error) {
+		var v version
+		_, err := fmt.Sscanf(s, "%d.%d", &v.Major, &v.Minor)
+		return v, err
+	})
+}
+
+type options struct {
+	Shared
+	Addr    string        `flag:"addr" usage:"address to listen on"`
+	Timeout time.Duration `flag:"timeout" usage:"request timeout"`
+	Poll    time.Duration `flag:"poll-ms" flagformat:"int,ms"`
+	Tags    []string      `flag:"tag" usage:"tag to apply (repeatable)"`
+	Hosts   []string      `flag:"hosts,join=','"`
+	Limit   *int          `flag:"limit"`
+	DB      database      `flag:"db"`
+	Skipped string        `flag:"-"`
+}
+
+var _ = Describe("Flags", func() {
+	var (
+		fs   *flag.FlagSet
+		opts options
+	)
+
+	BeforeEach(func() {
+		fs = flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+		opts = options{Addr: ":8080", Tags: []string{"default"}, DB: database{Host: "localhost"}}
+		Expect(flags.Register(fs, &opts)).To(Succeed())
+	})
+
+	It("parses every kind of field", func() {
+		err := fs.Parse([]string{
+			"-v",
+			"-addr", ":9090",
+			"-timeout", "1m30s",
+			"-poll-ms", "250",
+			"-tag", "a", "-tag", "b",
+			"-hosts", "x,y", "-hosts", "z",
+			"-limit", "10",
+			"-db.port", "5432",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		limit := 10
+		Expect(opts).To(Equal(options{
+			Shared:  Shared{Verbose: true},
+			Addr:    ":9090",
+			Timeout: 90 * time.Second,
+			Poll:    250 * time.Millisecond,
+			Tags:    []string{"a", "b"},
+			Hosts:   []string{"x", "y", "z"},
+			Limit:   &limit,
+			DB:      database{Host: "localhost", Port: 5432},
+		}))
+	})
+
+	It("uses the field values as defaults and the usage tag as help", func() {
+		f := fs.Lookup("addr")
+		Expect(f).NotTo(BeNil())
+		Expect(f.DefValue).To(Equal(":8080"))
+		Expect(f.Usage).To(Equal("address to listen on"))
+
+		Expect(fs.Lookup("tag").DefValue).To(Equal("default"))
+		Expect(fs.Lookup("db.host").DefValue).To(Equal("localhost"))
+		Expect(fs.Lookup("Skipped")).To(BeNil())
+	})
+
+	It("keeps the defaults when flags are absent", func() {
+		Expect(fs.Parse(nil)).To(Succeed())
+		Expect(opts.Tags).To(Equal([]string{"default"}))
+		Expect(opts.Limit).To(BeNil())
+	})
+
+	It("fails on values that cannot be parsed", func() {
+		Expect(fs.Parse([]string{"-timeout", "soon"})).To(HaveOccurred())
+		Expect(fs.Parse([]string{"-db.port", "http"})).To(HaveOccurred())
+	})
+
+	It("registers struct types with a codec as single flags", func() {
+		var target struct {
+			Version version  `flag:"version"`
+			Min     *version `flag:"min"`
+		}
+		target.Version = version{1, 0}
+
+		fs := flag.NewFlagSet("codec", flag.ContinueOnError)
+		Expect(flags.Register(fs, &target)).To(Succeed())
+		Expect(fs.Lookup("version.Major")).To(BeNil())
+		Expect(fs.Lookup("version").DefValue).To(Equal("1.0"))
+
+		Expect(fs.Parse([]string{"-version", "2.3", "-min", "1.5"})).To(Succeed())
+		Expect(target.Version).To(Equal(version{2, 3}))
+		Expect(target.Min).To(Equal(&version{1, 5}))
+	})
+
+	It("registers standard library value types as single flags", func() {
+		var target struct {
+			Addr  netip.Addr `flag:"addr"`
+			URL   *url.URL   `flag:"u"`
+			Total big.Int    `flag:"n"`
+		}
+
+		fs := flag.NewFlagSet("stdlib", flag.ContinueOnError)
+		Expect(flags.Register(fs, &target)).To(Succeed())
+		Expect(fs.Lookup("u.Host")).To(BeNil())
+
+		Expect(fs.Parse([]string{"-addr", "10.0.0.1", "-u", "http://x", "-n", "5"})).To(Succeed())
+		Expect(target.Addr).To(Equal(netip.MustParseAddr("10.0.0.1")))
+		Expect(target.URL.String()).To(Equal("http://x"))
+		Expect(target.Total.Int64()).To(BeEquivalentTo(5))
+		Expect(fs.Lookup("n").Value.String()).To(Equal("5"))
+	})
+
+	It("fails on duplicate flag names", func() {
+		dup := struct {
+			A string `flag:"a"`
+			B string `flag:"a"`
+		}{}
+		Expect(flags.Register(flag.NewFlagSet("dup", flag.ContinueOnError), &dup)).To(HaveOccurred())
+	})
+
+	It("fails on a non-struct pointer", func() {
+		Expect(flags.Register(fs, options{})).To(HaveOccurred())
+		Expect(flags.Register(nil, &options{})).To(HaveOccurred())
+	})
+})
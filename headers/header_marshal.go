@@ -0,0 +1,130 @@
+package headers // import "go.gideaworx.io/go-encoding/headers"
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+const defaultJoin = ", "
+
+// HeaderMarshaler lets implementations convert themselves into an http.Header object.
+type HeaderMarshaler interface {
+	MarshalHeader() (http.Header, error)
+}
+
+// MarshalHeader will take a struct (or a non-nil pointer to one, or a HeaderMarshaler) and serialize its exported
+// fields into an http.Header. The header names can be controlled by the "header" struct tag, which uses the same
+// syntax as the "url" tag in the urlvalues package. For example, given the struct
+//
+//	type Example struct {
+//		RequestID   string        `header:"x-request-id"`
+//		Accept      []string      `header:"Accept"`
+//		IfModified  time.Time     `header:"If-Modified-Since,omitempty"`
+//		Timeout     time.Duration `header:"X-Timeout" headerformat:"int,ms"`
+//		Internal    string        `header:"-"`
+//	}
+//
+// header names are canonicalized (x-request-id becomes X-Request-Id), slices and arrays are joined into a single
+// header value with ", " (or the tag's join option), and time.Time values are written in the RFC1123 format HTTP uses.
+// The "headerformat" tag accepts the same formats as the "urlformat" tag, and values are otherwise converted exactly
+// as urlvalues.MarshalURLValues converts them.
+func MarshalHeader(a any) (http.Header, error) {
+	if m, ok := a.(HeaderMarshaler); ok {
+		return m.MarshalHeader()
+	}
+
+	if a == nil {
+		return http.Header{}, errors.New("value cannot be nil")
+	}
+
+	v := reflect.ValueOf(a)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return http.Header{}, errors.New("value cannot be nil")
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return http.Header{}, errors.New("argument must be a struct or non-nil pointer to a struct")
+	}
+
+	h := http.Header{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, err := fieldTag(sf)
+		if err != nil {
+			if errors.Is(err, urlvalues.ErrSkip) {
+				continue
+			}
+
+			return http.Header{}, err
+		}
+
+		fv := v.Field(i)
+		if tag.Omits(fv) {
+			continue
+		}
+
+		format := sf.Tag.Get("headerformat")
+		valueStrings, err := urlvalues.FormatValuesFunc(fv, func(v reflect.Value) (string, error) {
+			return formatValue(v, format)
+		})
+		if err != nil {
+			return http.Header{}, err
+		}
+
+		if len(valueStrings) > 0 {
+			h.Set(tag.Name, strings.Join(valueStrings, tag.Join))
+		}
+	}
+
+	return h, nil
+}
+
+// fieldTag parses the header tag of sf, filling in the canonical header name and the default join string.
+func fieldTag(sf reflect.StructField) (urlvalues.Tag, error) {
+	tag := urlvalues.Tag{Name: sf.Name}
+	if tagString, ok := sf.Tag.Lookup("header"); ok {
+		var err error
+		if tag, err = urlvalues.ParseTag(tagString); err != nil {
+			return tag, err
+		}
+
+		if tag.Name == "" {
+			tag.Name = sf.Name
+		}
+	}
+
+	if tag.Join == "" {
+		tag.Join = defaultJoin
+	}
+
+	tag.Name = http.CanonicalHeaderKey(tag.Name)
+	return tag, nil
+}
+
+func formatValue(v reflect.Value, format string) (string, error) {
+	if format == "" {
+		if v.Kind() == reflect.Pointer && !v.IsNil() {
+			v = v.Elem()
+		}
+
+		if t, ok := v.Interface().(time.Time); ok {
+			return t.UTC().Format(http.TimeFormat), nil
+		}
+	}
+
+	return urlvalues.FormatValue(v, format)
+}
@@ -0,0 +1,144 @@
+package headers_test
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/headers"
+)
+
+type gatewayHeaders struct {
+	RequestID   string        `header:"x-request-id"`
+	Accept      []string      `header:"Accept"`
+	Codes       []int         `header:"X-Codes,join=';'"`
+	IfModified  time.Time     `header:"If-Modified-Since,omitempty"`
+	Expires     *time.Time    `header:"Expires"`
+	Timeout     time.Duration `header:"X-Timeout" headerformat:"int,ms"`
+	Debug       bool          `header:"X-Debug" headerformat:"int"`
+	Retries     int
+	Skipped     string `header:"-"`
+	Unset       *string
+	notExported string
+}
+
+var _ = Describe("Headers", func() {
+	modified := time.Date(2022, time.July, 3, 12, 22, 9, 0, time.UTC)
+
+	g := gatewayHeaders{
+		RequestID:  "abc-123",
+		Accept:     []string{"text/html", "application/json"},
+		Codes:      []int{1, 2, 3},
+		IfModified: modified,
+		Expires:    &modified,
+		Timeout:    1500 * time.Millisecond,
+		Debug:      true,
+		Retries:    3,
+		Skipped:    "skipped",
+	}
+
+	expected := http.Header{
+		"X-Request-Id":      {"abc-123"},
+		"Accept":            {"text/html, application/json"},
+		"X-Codes":           {"1;2;3"},
+		"If-Modified-Since": {"Sun, 03 Jul 2022 12:22:09 GMT"},
+		"Expires":           {"Sun, 03 Jul 2022 12:22:09 GMT"},
+		"X-Timeout":         {"1500"},
+		"X-Debug":           {"1"},
+		"Retries":           {"3"},
+	}
+
+	Describe("Marshaling", func() {
+		It("marshals a struct", func() {
+			h, err := headers.MarshalHeader(g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h).To(Equal(expected))
+		})
+
+		It("marshals a struct pointer", func() {
+			h, err := headers.MarshalHeader(&g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h).To(Equal(expected))
+		})
+
+		It("writes times in UTC", func() {
+			h, err := headers.MarshalHeader(struct{ Date time.Time }{modified.In(time.FixedZone("X", 3600))})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h.Get("Date")).To(Equal("Sun, 03 Jul 2022 12:22:09 GMT"))
+		})
+
+		It("applies the omit options the way urlvalues does", func() {
+			h, err := headers.MarshalHeader(struct {
+				Since time.Time `header:"X-Since,omitzero"`
+				Tags  []string  `header:"X-Tags,omitempty"`
+				Limit *int      `header:"X-Limit,omitnil"`
+				Count int       `header:"X-Count"`
+			}{Since: time.Time{}.In(time.FixedZone("X", 3600)), Tags: []string{}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h).To(Equal(http.Header{"X-Count": {"0"}}))
+		})
+
+		It("fails on non-structs", func() {
+			_, err := headers.MarshalHeader(3)
+			Expect(err).To(HaveOccurred())
+
+			_, err = headers.MarshalHeader(nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Unmarshaling", func() {
+		It("unmarshals a struct", func() {
+			var out gatewayHeaders
+			Expect(headers.UnmarshalHeader(expected, &out)).To(Succeed())
+
+			want := g
+			want.Skipped = ""
+			Expect(out.Expires.Equal(modified)).To(BeTrue())
+			Expect(out.IfModified.Equal(modified)).To(BeTrue())
+			out.Expires, want.Expires = nil, nil
+			out.IfModified, want.IfModified = time.Time{}, time.Time{}
+			Expect(out).To(Equal(want))
+		})
+
+		It("accepts repeated headers and non-canonical keys", func() {
+			h := http.Header{
+				"x-request-id": {"lower"},
+				"Accept":       {"text/html,application/xml", " application/json "},
+			}
+
+			var out gatewayHeaders
+			Expect(headers.UnmarshalHeader(h, &out)).To(Succeed())
+			Expect(out.RequestID).To(Equal("lower"))
+			Expect(out.Accept).To(Equal([]string{"text/html", "application/xml", "application/json"}))
+		})
+
+		It("round-trips lists of dates", func() {
+			type dates struct {
+				Seen []time.Time `header:"X-Seen"`
+			}
+
+			in := dates{Seen: []time.Time{time.Unix(0, 0).UTC(), time.Unix(100, 0).UTC()}}
+			h, err := headers.MarshalHeader(in)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h.Get("X-Seen")).To(Equal("Thu, 01 Jan 1970 00:00:00 GMT, Thu, 01 Jan 1970 00:01:40 GMT"))
+
+			var out dates
+			Expect(headers.UnmarshalHeader(h, &out)).To(Succeed())
+			Expect(out).To(Equal(in))
+		})
+
+		It("reports the header that failed to parse", func() {
+			var out gatewayHeaders
+			err := headers.UnmarshalHeader(http.Header{"Retries": {"many"}}, &out)
+			Expect(err).To(MatchError(ContainSubstring("Retries")))
+		})
+
+		It("fails on a non-struct pointer", func() {
+			var s string
+			Expect(headers.UnmarshalHeader(http.Header{}, &s)).To(HaveOccurred())
+			Expect(headers.UnmarshalHeader(http.Header{}, gatewayHeaders{})).To(HaveOccurred())
+		})
+	})
+})
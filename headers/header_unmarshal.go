@@ -0,0 +1,148 @@
+package headers // import "go.gideaworx.io/go-encoding/headers"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// HeaderUnmarshaler allows implementations to decode an http.Header object in a custom way
+type HeaderUnmarshaler interface {
+	UnmarshalHeader(http.Header) error
+}
+
+// UnmarshalHeader will take an http.Header and deserialize it into the struct pointed to by a, which must be a
+// non-nil pointer to a struct or a HeaderUnmarshaler. Header names are matched using their canonical form, so a
+// field tagged `header:"x-request-id"` is populated from "X-Request-Id". For slice and array fields, every value of the
+// header is split on the tag's join option (or "," by default) and each element is trimmed of surrounding whitespace,
+// so both repeated headers and comma-joined values are accepted. time.Time fields default to the RFC1123 format HTTP
+// uses, and the "headerformat" tag accepts the same formats as the "urlformat" tag in the urlvalues package. Lists of
+// times are split around the commas their layout contains, so the dates MarshalHeader joins are read back intact.
+func UnmarshalHeader(h http.Header, a any) error {
+	if a == nil {
+		return errors.New("second argument must not be nil")
+	}
+
+	if um, ok := a.(HeaderUnmarshaler); ok {
+		return um.UnmarshalHeader(h)
+	}
+
+	v := reflect.ValueOf(a)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("second argument must be a non-nil pointer to a struct")
+	}
+
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, err := fieldTag(sf)
+		if err != nil {
+			if errors.Is(err, urlvalues.ErrSkip) {
+				continue
+			}
+
+			return err
+		}
+
+		values := lookup(h, tag.Name)
+		if len(values) == 0 {
+			continue
+		}
+
+		elemType := sf.Type
+		if elemType.Kind() == reflect.Pointer {
+			elemType = elemType.Elem()
+		}
+
+		isList := urlvalues.IsList(elemType)
+		if isList {
+			elemType = elemType.Elem()
+		}
+
+		format := sf.Tag.Get("headerformat")
+		if format == "" && elemType == timeType {
+			format = http.TimeFormat
+		}
+
+		if isList {
+			span := 1
+			if elemType == timeType {
+				// a layout can contain the separator itself, like the "Mon, 02 Jan 2006 15:04:05 GMT" dates HTTP uses
+				span += strings.Count(format, separator(tag.Join))
+			}
+
+			values = split(values, tag.Join, span)
+		} else {
+			values = values[:1]
+		}
+
+		parsedValue, err := urlvalues.ParseValues(values, sf.Type, format, "")
+		if err != nil {
+			return fmt.Errorf("header %s: %w", tag.Name, err)
+		}
+
+		if tag.OmitEmpty && (!parsedValue.IsValid() || parsedValue.IsZero()) {
+			continue
+		}
+
+		v.Field(i).Set(parsedValue)
+	}
+
+	return nil
+}
+
+// lookup finds the values for the canonical header key, falling back to a case-insensitive search for headers that
+// were added to the map directly with a non-canonical key.
+func lookup(h http.Header, key string) []string {
+	if values := h.Values(key); len(values) > 0 {
+		return values
+	}
+
+	for k, values := range h {
+		if strings.EqualFold(k, key) {
+			return values
+		}
+	}
+
+	return nil
+}
+
+// separator returns the string that list values are split on: join without its surrounding whitespace, since
+// elements are trimmed after splitting, unless join is only whitespace.
+func separator(join string) string {
+	if sep := strings.TrimSpace(join); sep != "" {
+		return sep
+	}
+
+	return join
+}
+
+// split splits every value on the separator of join, treating each run of span parts as a single element.
+func split(values []string, join string, span int) []string {
+	sep := separator(join)
+
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		pieces := strings.Split(value, sep)
+		for i := 0; i < len(pieces); i += span {
+			part := strings.Join(pieces[i:min(i+span, len(pieces))], sep)
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+	}
+
+	return parts
+}
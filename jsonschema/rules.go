@@ -0,0 +1,103 @@
+package jsonschema // import "go.gideaworx.io/go-encoding/jsonschema"
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/openapi"
+)
+
+var oneOfValues = regexp.MustCompile(`'[^']*'|\S+`)
+
+// applyRules adds the constraints of a "validate" tag to schema, reporting whether the tag makes the field required.
+func applyRules(schema *Schema, t reflect.Type, format string, tag string) (bool, error) {
+	if tag == "" {
+		return false, nil
+	}
+
+	required := false
+	target, targetType := schema, t
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = required || target == schema
+		case "dive":
+			if target.Type != "array" {
+				return false, fmt.Errorf("dive on non-array type %s", targetType)
+			}
+
+			target, targetType = target.Items, targetType.Elem()
+		case "oneof":
+			if target.Type == "array" {
+				return false, fmt.Errorf("oneof on array type %s requires dive", targetType)
+			}
+
+			for _, s := range oneOfValues.FindAllString(param, -1) {
+				v, err := openapi.DefaultValue(strings.Trim(s, "'"), targetType, format, "")
+				if err != nil {
+					return false, fmt.Errorf("invalid oneof rule: %w", err)
+				}
+
+				target.Enum = append(target.Enum, v)
+			}
+		case "min", "max", "len":
+			if err := applyBound(target, targetType, name, param); err != nil {
+				return false, fmt.Errorf("invalid %s rule: %w", name, err)
+			}
+		}
+	}
+
+	return required, nil
+}
+
+// applyBound applies a min, max or len rule, which bounds numbers by value, strings by length and arrays by item
+// count. It has no JSON Schema equivalent for times and durations, so it is ignored for those.
+func applyBound(schema *Schema, t reflect.Type, name string, param string) error {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case schema.Type == "integer" || schema.Type == "number":
+		if len(schema.Enum) > 0 {
+			return nil
+		}
+
+		f, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return err
+		}
+
+		setBounds(name, &f, &schema.Minimum, &schema.Maximum)
+	case schema.Type == "string" && t.Kind() == reflect.String:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return err
+		}
+
+		setBounds(name, &n, &schema.MinLength, &schema.MaxLength)
+	case schema.Type == "array":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return err
+		}
+
+		setBounds(name, &n, &schema.MinItems, &schema.MaxItems)
+	}
+
+	return nil
+}
+
+func setBounds[T any](name string, v *T, lower, upper **T) {
+	if name != "max" {
+		*lower = v
+	}
+
+	if name != "min" {
+		*upper = v
+	}
+}
@@ -0,0 +1,137 @@
+package jsonschema // import "go.gideaworx.io/go-encoding/jsonschema"
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.gideaworx.io/go-encoding/openapi"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// Draft is the JSON Schema dialect the generated documents declare.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of a JSON Schema document needed to describe a form.
+type Schema struct {
+	Schema     string             `json:"$schema,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Pattern    string             `json:"pattern,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Enum       []any              `json:"enum,omitempty"`
+	Default    any                `json:"default,omitempty"`
+	Minimum    *float64           `json:"minimum,omitempty"`
+	Maximum    *float64           `json:"maximum,omitempty"`
+	MinLength  *int               `json:"minLength,omitempty"`
+	MaxLength  *int               `json:"maxLength,omitempty"`
+	MinItems   *int               `json:"minItems,omitempty"`
+	MaxItems   *int               `json:"maxItems,omitempty"`
+}
+
+// Generate returns a JSON Schema describing the form that urlvalues.UnmarshalURLValues decodes into the struct type t
+// (or a pointer to one). It walks the same urlvalues.Fields metadata as the marshaler, so each property is named after
+// its parameter and typed after the decoded field:
+//
+//   - integers, numbers and booleans keep their JSON types, while a bool with a "urlformat" tag becomes an enum of
+//     its formatted values
+//   - time.Time is a date-time (or date, for urlformat:"2006-01-02") string, and time.Duration is a string in Go
+//     syntax such as "1h30m", matched by a pattern since the "duration" format means ISO 8601, or an integer when it
+//     is formatted as one
+//   - repeated and joined fields are arrays of their element schema
+//   - fields tagged with the required option and no default, or with a "validate" tag containing required, are listed
+//     as required
+//
+// The "validate" tag uses the rule syntax of github.com/go-playground/validator: oneof becomes an enum, min, max and
+// len constrain the value, length or item count depending on the type, and rules after dive apply to the items of an
+// array. Other rules are ignored. Path parameters are not part of the form and are left out.
+func Generate(t reflect.Type) (*Schema, error) {
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	fields, err := urlvalues.Fields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &Schema{Schema: Draft, Type: "object", Properties: map[string]*Schema{}}
+	for _, f := range fields {
+		if f.In != urlvalues.InQuery {
+			continue
+		}
+
+		property, required, err := fieldSchema(f)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.StructField.Name, err)
+		}
+
+		schema.Properties[f.Name] = property
+		if required {
+			schema.Required = append(schema.Required, f.Name)
+		}
+	}
+
+	return schema, nil
+}
+
+func fieldSchema(f urlvalues.Field) (*Schema, bool, error) {
+	t := f.StructField.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	schema, err := typeSchema(t, f.Format)
+	if err != nil {
+		return nil, false, err
+	}
+
+	required, err := applyRules(schema, t, f.Format, f.StructField.Tag.Get("validate"))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if f.Tag.HasDefault {
+		if schema.Default, err = openapi.DefaultValue(f.Tag.Default, t, f.Format, f.Tag.Join); err != nil {
+			return nil, false, fmt.Errorf("invalid default: %w", err)
+		}
+	}
+
+	return schema, required || (f.Tag.Required && !f.Tag.HasDefault), nil
+}
+
+// typeSchema returns the JSON Schema of a single value of type t, given the value of its "urlformat" tag. It is the
+// schema openapi.SchemaFor describes, without the OpenAPI formats for the size of numbers, which JSON Schema does not
+// define.
+func typeSchema(t reflect.Type, format string) (*Schema, error) {
+	s, err := openapi.SchemaFor(t, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromOpenAPI(s), nil
+}
+
+func fromOpenAPI(s *openapi.Schema) *Schema {
+	schema := &Schema{
+		Type:     s.Type,
+		Format:   s.Format,
+		Pattern:  s.Pattern,
+		Enum:     s.Enum,
+		Minimum:  s.Minimum,
+		MinItems: s.MinItems,
+		MaxItems: s.MaxItems,
+	}
+
+	switch s.Format {
+	case "int32", "int64", "float", "double":
+		schema.Format = ""
+	}
+
+	if s.Items != nil {
+		schema.Items = fromOpenAPI(s.Items)
+	}
+
+	return schema
+}
@@ -0,0 +1,133 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/netip"
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/jsonschema"
+)
+
+type signupForm struct {
+	ID         string        `path:"id"`
+	Email      string        `url:"email,required" validate:"required,min=3,max=254"`
+	Plan       string        `url:"plan,required,default=free" validate:"oneof=free pro 'big team'"`
+	Seats      int           `url:"seats" validate:"min=1,max=50"`
+	Ratio      float64       `url:"ratio"`
+	Age        *uint8        `url:"age"`
+	Newsletter bool          `url:"newsletter" urlformat:"int"`
+	Birthday   time.Time     `url:"birthday" urlformat:"2006-01-02"`
+	StartsAt   time.Time     `url:"starts_at"`
+	Trial      time.Duration `url:"trial" validate:"min=1h"`
+	Timeout    time.Duration `url:"timeout" urlformat:"int,s"`
+	Topics     []string      `url:"topic" validate:"min=1,dive,oneof=go rust"`
+	Scores     []int         `url:"scores,join=','" validate:"dive,max=10"`
+	Pin        [4]int        `url:"pin"`
+	Skipped    string        `url:"-"`
+}
+
+var _ = Describe("Generate", func() {
+	It("describes a form", func() {
+		schema, err := jsonschema.Generate(reflect.TypeOf(&signupForm{}))
+		Expect(err).NotTo(HaveOccurred())
+
+		b, err := json.Marshal(schema)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"email": {"type": "string", "minLength": 3, "maxLength": 254},
+				"plan": {"type": "string", "enum": ["free", "pro", "big team"], "default": "free"},
+				"seats": {"type": "integer", "minimum": 1, "maximum": 50},
+				"ratio": {"type": "number"},
+				"age": {"type": "integer", "minimum": 0},
+				"newsletter": {"type": "integer", "enum": [0, 1]},
+				"birthday": {"type": "string", "format": "date"},
+				"starts_at": {"type": "string", "format": "date-time"},
+				"trial": {"type": "string", "pattern": "^[-+]?(0|((\\d+(\\.\\d*)?|\\.\\d+)(ns|us|µs|μs|ms|s|m|h))+)$"},
+				"timeout": {"type": "integer"},
+				"topic": {"type": "array", "minItems": 1, "items": {"type": "string", "enum": ["go", "rust"]}},
+				"scores": {"type": "array", "items": {"type": "integer", "maximum": 10}},
+				"pin": {"type": "array", "items": {"type": "integer"}, "minItems": 4, "maxItems": 4}
+			},
+			"required": ["email"]
+		}`))
+	})
+
+	It("types enum values after the field", func() {
+		type levels struct {
+			Level  int  `url:"level,default=2" validate:"required,oneof=1 2 3"`
+			Strict bool `url:"strict" urlformat:"upper" validate:"oneof=TRUE"`
+		}
+
+		schema, err := jsonschema.Generate(reflect.TypeOf(levels{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schema.Required).To(Equal([]string{"level"}))
+		Expect(schema.Properties["level"].Enum).To(Equal([]any{int64(1), int64(2), int64(3)}))
+		Expect(schema.Properties["level"].Default).To(Equal(int64(2)))
+		Expect(schema.Properties["strict"].Enum).To(ContainElement("TRUE"))
+	})
+
+	It("describes standard library value types", func() {
+		type schedule struct {
+			Month   time.Month     `url:"month,default=march"`
+			Days    []time.Weekday `url:"day" urlformat:"name"`
+			Server  net.IP         `url:"server"`
+			Network *netip.Prefix  `url:"network"`
+			Total   big.Int        `url:"total"`
+		}
+
+		schema, err := jsonschema.Generate(reflect.TypeOf(schedule{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schema.Properties["month"].Type).To(Equal("integer"))
+		Expect(schema.Properties["month"].Enum).To(BeEmpty())
+		Expect(schema.Properties["month"].Default).To(BeEquivalentTo(3))
+		Expect(schema.Properties["day"].Items.Type).To(Equal("string"))
+		Expect(schema.Properties["day"].Items.Enum).To(HaveLen(7))
+		Expect(schema.Properties["day"].Items.Enum).To(ContainElement("Monday"))
+		Expect(schema.Properties["server"].Type).To(Equal("string"))
+		Expect(schema.Properties["network"].Type).To(Equal("string"))
+		Expect(schema.Properties["total"].Type).To(Equal("string"))
+	})
+
+	It("rejects invalid rules", func() {
+		type badOneOf struct {
+			Count int `url:"count" validate:"oneof=one two"`
+		}
+
+		type badDive struct {
+			Name string `url:"name" validate:"dive,min=1"`
+		}
+
+		type badArrayOneOf struct {
+			Names []string `url:"name" validate:"oneof=a b"`
+		}
+
+		_, err := jsonschema.Generate(reflect.TypeOf(badOneOf{}))
+		Expect(err).To(MatchError(ContainSubstring("invalid oneof rule")))
+
+		_, err = jsonschema.Generate(reflect.TypeOf(badDive{}))
+		Expect(err).To(MatchError(ContainSubstring("dive on non-array")))
+
+		_, err = jsonschema.Generate(reflect.TypeOf(badArrayOneOf{}))
+		Expect(err).To(MatchError(ContainSubstring("requires dive")))
+	})
+
+	It("rejects unsupported types", func() {
+		type badForm struct {
+			Values map[string]string `url:"values"`
+		}
+
+		_, err := jsonschema.Generate(reflect.TypeOf(badForm{}))
+		Expect(err).To(MatchError(ContainSubstring("field Values")))
+
+		_, err = jsonschema.Generate(reflect.TypeOf("form"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,88 @@
+package multipartform // import "go.gideaworx.io/go-encoding/multipartform"
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"reflect"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var (
+	readerType          = reflect.TypeOf((*io.Reader)(nil)).Elem()
+	bytesType           = reflect.TypeOf([]byte(nil))
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+type formField struct {
+	urlvalues.Tag
+	filename string
+	format   string
+}
+
+// parseField reads the "url" and "urlformat" tags of sf. In addition to the usual options, the url tag may contain
+// filename=... to name the file part written for a file field.
+func parseField(sf reflect.StructField) (*formField, error) {
+	f := &formField{
+		Tag:    urlvalues.Tag{Name: sf.Name},
+		format: sf.Tag.Get("urlformat"),
+	}
+
+	tagString, ok := sf.Tag.Lookup("url")
+	if !ok {
+		if _, hasPath := sf.Tag.Lookup("path"); hasPath {
+			return nil, urlvalues.ErrSkip
+		}
+
+		return f, nil
+	}
+
+	tag, err := urlvalues.ParseTag(tagString)
+	if err != nil {
+		return nil, err
+	}
+
+	f.Tag = tag
+	if f.Name == "" {
+		f.Name = sf.Name
+	}
+
+	for _, option := range strings.Split(tagString, ",")[1:] {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(option), "filename="); ok {
+			f.filename = name
+		}
+	}
+
+	return f, nil
+}
+
+// isFileType reports whether fields of type t are written as file parts rather than form fields.
+func isFileType(t reflect.Type) bool {
+	return t == bytesType || t == fileHeaderType || t == fileHeaderSliceType || t.Implements(readerType)
+}
+
+func structValue(a any, pointerRequired bool) (reflect.Value, error) {
+	if a == nil {
+		return reflect.Value{}, errors.New("value cannot be nil")
+	}
+
+	v := reflect.ValueOf(a)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, errors.New("value cannot be nil")
+		}
+
+		v = v.Elem()
+	} else if pointerRequired {
+		return reflect.Value{}, errors.New("argument must be a non-nil pointer to a struct")
+	}
+
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, errors.New("argument must be a struct or non-nil pointer to a struct")
+	}
+
+	return v, nil
+}
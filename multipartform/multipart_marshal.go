@@ -0,0 +1,202 @@
+package multipartform // import "go.gideaworx.io/go-encoding/multipartform"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+const defaultContentType = "application/octet-stream"
+
+// Marshal writes the exported fields of a (a struct or a non-nil pointer to one) to w as multipart/form-data parts,
+// in field order. Part names come from the same "url" and "urlformat" struct tags that urlvalues.MarshalURLValues
+// uses, so one struct can describe both the urlencoded and the multipart form of a request. For example
+//
+//	type Upload struct {
+//		Title  string                `url:"title"`
+//		Tags   []string              `url:"tag"`
+//		Avatar io.Reader             `url:"avatar,filename=avatar.png"`
+//		Notes  []byte                `url:"notes,omitempty"`
+//		Copy   *multipart.FileHeader `url:"copy"`
+//	}
+//
+// Scalar fields (and each element of slice fields, unless a join option is given) are written as form fields using
+// the urlvalues conversion rules. Fields of type []byte, *multipart.FileHeader, []*multipart.FileHeader, or any type
+// implementing io.Reader are written as file parts. Readers are copied straight into the part, so their content is
+// never buffered. The part's filename is taken from the filename tag option, then the file header or the reader's
+// Name() method (as with *os.File), and finally the part name. Marshal does not close w.
+func Marshal(w *multipart.Writer, a any) error {
+	if w == nil {
+		return errors.New("writer must not be nil")
+	}
+
+	v, err := structValue(a, false)
+	if err != nil {
+		return err
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		f, err := parseField(sf)
+		if err != nil {
+			if errors.Is(err, urlvalues.ErrSkip) {
+				continue
+			}
+
+			return err
+		}
+
+		fv := v.Field(i)
+		if f.Omits(fv) {
+			continue
+		}
+
+		if isFileType(sf.Type) {
+			if err := writeFiles(w, f, fv); err != nil {
+				return fmt.Errorf("field %s: %w", sf.Name, err)
+			}
+
+			continue
+		}
+
+		if err := writeFields(w, f, fv); err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// NewBody streams the multipart encoding of a through a pipe, so it can be used as an http.Request body without
+// holding the whole form in memory. It returns the body and the Content-Type header to send with it. Errors from
+// Marshal are reported by the body's Read method.
+func NewBody(a any) (io.ReadCloser, string) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		err := Marshal(mw, a)
+		if err == nil {
+			err = mw.Close()
+		}
+
+		pw.CloseWithError(err)
+	}()
+
+	return pr, mw.FormDataContentType()
+}
+
+func writeFields(w *multipart.Writer, f *formField, fv reflect.Value) error {
+	valueStrings, err := urlvalues.FormatValues(fv, f.format)
+	if err != nil {
+		return err
+	}
+
+	if f.Join != "" {
+		if len(valueStrings) == 0 {
+			return nil
+		}
+
+		return w.WriteField(f.Name, strings.Join(valueStrings, f.Join))
+	}
+
+	for _, str := range valueStrings {
+		if err := w.WriteField(f.Name, str); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFiles(w *multipart.Writer, f *formField, fv reflect.Value) error {
+	if !fv.IsValid() || ((fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface) && fv.IsNil()) {
+		return nil
+	}
+
+	switch content := fv.Interface().(type) {
+	case []byte:
+		if content == nil {
+			return nil
+		}
+
+		return writeFile(w, f.Name, f.filename, "", bytes.NewReader(content))
+	case []*multipart.FileHeader:
+		for _, fh := range content {
+			if err := writeFileHeader(w, f, fh); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *multipart.FileHeader:
+		return writeFileHeader(w, f, content)
+	case io.Reader:
+		filename := f.filename
+		if named, ok := content.(interface{ Name() string }); ok && filename == "" {
+			filename = filepath.Base(named.Name())
+		}
+
+		return writeFile(w, f.Name, filename, "", content)
+	}
+
+	return fmt.Errorf("unsupported file type %s", fv.Type())
+}
+
+func writeFileHeader(w *multipart.Writer, f *formField, fh *multipart.FileHeader) error {
+	if fh == nil {
+		return nil
+	}
+
+	file, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	filename := f.filename
+	if filename == "" {
+		filename = fh.Filename
+	}
+
+	return writeFile(w, f.Name, filename, fh.Header.Get("Content-Type"), file)
+}
+
+func writeFile(w *multipart.Writer, name, filename, contentType string, r io.Reader) error {
+	if filename == "" {
+		filename = name
+	}
+
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+		"name":     name,
+		"filename": filename,
+	}))
+	h.Set("Content-Type", contentType)
+
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, r)
+	return err
+}
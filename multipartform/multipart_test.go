@@ -0,0 +1,140 @@
+package multipartform_test
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/multipartform"
+)
+
+type upload struct {
+	Title   string                  `url:"title"`
+	Tags    []string                `url:"tag"`
+	Joined  []int                   `url:"joined,join=','"`
+	Delay   time.Duration           `url:"delay" urlformat:"int,ms"`
+	Avatar  io.Reader               `url:"avatar,filename=avatar.png"`
+	Notes   []byte                  `url:"notes,omitempty"`
+	Copies  []*multipart.FileHeader `url:"copy"`
+	Skipped string                  `url:"-"`
+}
+
+type received struct {
+	Title  string                `url:"title"`
+	Tags   []string              `url:"tag"`
+	Joined []int                 `url:"joined,join=','"`
+	Delay  time.Duration         `url:"delay" urlformat:"int,ms"`
+	Avatar *multipart.FileHeader `url:"avatar"`
+	Notes  []byte                `url:"notes"`
+	Copies io.ReadCloser         `url:"copy"`
+}
+
+var _ = Describe("Multipart Forms", func() {
+	decode := func(body io.Reader, contentType string, maxMemory int64, a any) *multipartform.Decoder {
+		_, params, err := mime.ParseMediaType(contentType)
+		Expect(err).NotTo(HaveOccurred())
+
+		d := multipartform.NewDecoder(body, params["boundary"])
+		d.SetMaxMemory(maxMemory)
+		Expect(d.Decode(a)).To(Succeed())
+		DeferCleanup(d.RemoveAll)
+
+		return d
+	}
+
+	It("round trips scalar fields and files", func() {
+		avatar := strings.Repeat("png", 1000)
+		body, contentType := multipartform.NewBody(upload{
+			Title:  "hello",
+			Tags:   []string{"a", "b"},
+			Joined: []int{1, 2},
+			Delay:  time.Second,
+			Avatar: strings.NewReader(avatar),
+			Notes:  []byte("some notes"),
+		})
+
+		var r received
+		decode(body, contentType, 10, &r)
+
+		Expect(r.Title).To(Equal("hello"))
+		Expect(r.Tags).To(Equal([]string{"a", "b"}))
+		Expect(r.Joined).To(Equal([]int{1, 2}))
+		Expect(r.Delay).To(Equal(time.Second))
+		Expect(r.Notes).To(Equal([]byte("some notes")))
+		Expect(r.Copies).To(BeNil())
+
+		Expect(r.Avatar.Filename).To(Equal("avatar.png"))
+		Expect(r.Avatar.Size).To(BeEquivalentTo(len(avatar)))
+		f, err := r.Avatar.Open()
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+		Expect(io.ReadAll(f)).To(BeEquivalentTo(avatar))
+	})
+
+	It("re-encodes received file headers and opens files for reader fields", func() {
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		Expect(multipartform.Marshal(w, &upload{Avatar: strings.NewReader("first")})).To(Succeed())
+		Expect(w.Close()).To(Succeed())
+
+		var r received
+		decode(&buf, w.FormDataContentType(), multipartform.DefaultMaxMemory, &r)
+
+		body, contentType := multipartform.NewBody(upload{Copies: []*multipart.FileHeader{r.Avatar, r.Avatar}})
+
+		var again received
+		decode(body, contentType, multipartform.DefaultMaxMemory, &again)
+		Expect(again.Copies).NotTo(BeNil())
+		defer again.Copies.Close()
+		Expect(io.ReadAll(again.Copies)).To(BeEquivalentTo("first"))
+	})
+
+	It("falls back to form values for byte slices", func() {
+		form := &multipart.Form{Value: map[string][]string{"notes": {"text"}}}
+
+		var r received
+		Expect(multipartform.Unmarshal(form, &r)).To(Succeed())
+		Expect(r.Notes).To(Equal([]byte("text")))
+	})
+
+	It("applies the omit options the way urlvalues does", func() {
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		Expect(multipartform.Marshal(w, struct {
+			Since time.Time `url:"since,omitzero"`
+			Notes []byte    `url:"notes,omitempty"`
+			Count int       `url:"count"`
+		}{Since: time.Time{}.In(time.FixedZone("X", 3600)), Notes: []byte{}})).To(Succeed())
+		Expect(w.Close()).To(Succeed())
+
+		var names []string
+		r := multipart.NewReader(&buf, w.Boundary())
+		for {
+			part, err := r.NextPart()
+			if err == io.EOF {
+				break
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			names = append(names, part.FormName())
+		}
+
+		Expect(names).To(Equal([]string{"count"}))
+	})
+
+	It("reports body errors through the reader", func() {
+		body, _ := multipartform.NewBody(42)
+		_, err := io.ReadAll(body)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails on a non-struct pointer", func() {
+		Expect(multipartform.Unmarshal(&multipart.Form{}, received{})).To(HaveOccurred())
+		Expect(multipartform.Unmarshal(nil, &received{})).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,203 @@
+package multipartform // import "go.gideaworx.io/go-encoding/multipartform"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// DefaultMaxMemory is the number of bytes of file content a Decoder keeps in memory before spilling files to
+// temporary files on disk. It matches the default used by (*http.Request).FormFile.
+const DefaultMaxMemory = 32 << 20
+
+// Unmarshal populates the struct pointed to by a from form, using the same tags as Marshal. Form fields are converted
+// with the rules of urlvalues.UnmarshalURLValues. File parts are assigned according to the field type:
+//
+//   - *multipart.FileHeader receives the first file with the field's name
+//   - []*multipart.FileHeader receives every file with the field's name
+//   - []byte receives the content of the first file, read fully into memory (or the form field's value if no file
+//     was sent)
+//   - io.Reader, io.ReadCloser, multipart.File, or any other interface multipart.File satisfies receives the opened
+//     first file. The caller is responsible for closing it.
+//
+// Prefer the file header fields for large uploads, as they leave the content where the form put it (in memory or on
+// disk) until it is needed.
+func Unmarshal(form *multipart.Form, a any) error {
+	if form == nil {
+		return errors.New("form must not be nil")
+	}
+
+	v, err := structValue(a, true)
+	if err != nil {
+		return err
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		f, err := parseField(sf)
+		if err != nil {
+			if errors.Is(err, urlvalues.ErrSkip) {
+				continue
+			}
+
+			return err
+		}
+
+		if isFileType(sf.Type) {
+			if err := readFiles(form, f, v.Field(i)); err != nil {
+				return fmt.Errorf("field %s: %w", sf.Name, err)
+			}
+
+			continue
+		}
+
+		values := form.Value[f.Name]
+		if len(values) == 0 {
+			continue
+		}
+
+		parsedValue, err := urlvalues.ParseValues(values, sf.Type, f.format, f.Join)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+
+		if f.OmitEmpty && (!parsedValue.IsValid() || parsedValue.IsZero()) {
+			continue
+		}
+
+		v.Field(i).Set(parsedValue)
+	}
+
+	return nil
+}
+
+// UnmarshalRequest parses the multipart body of r, keeping at most maxMemory bytes of file content in memory, and
+// unmarshals it into a. Temporary files are removed by the http.Server once the handler returns.
+func UnmarshalRequest(r *http.Request, maxMemory int64, a any) error {
+	if r == nil {
+		return errors.New("request must not be nil")
+	}
+
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		return err
+	}
+
+	return Unmarshal(r.MultipartForm, a)
+}
+
+// Decoder reads a multipart/form-data stream and unmarshals it into structs.
+type Decoder struct {
+	r         *multipart.Reader
+	maxMemory int64
+	form      *multipart.Form
+}
+
+// NewDecoder returns a Decoder that reads parts separated by boundary from r. The boundary is the "boundary"
+// parameter of the request's Content-Type.
+func NewDecoder(r io.Reader, boundary string) *Decoder {
+	return &Decoder{
+		r:         multipart.NewReader(r, boundary),
+		maxMemory: DefaultMaxMemory,
+	}
+}
+
+// SetMaxMemory sets the number of bytes of file content that are kept in memory. Files beyond this limit are
+// streamed to temporary files on disk instead of being buffered.
+func (d *Decoder) SetMaxMemory(n int64) {
+	d.maxMemory = n
+}
+
+// Decode reads the whole form and unmarshals it into a. Any temporary files are kept until RemoveAll is called.
+func (d *Decoder) Decode(a any) error {
+	if d.form == nil {
+		form, err := d.r.ReadForm(d.maxMemory)
+		if err != nil {
+			return err
+		}
+
+		d.form = form
+	}
+
+	return Unmarshal(d.form, a)
+}
+
+// RemoveAll removes any temporary files created by Decode.
+func (d *Decoder) RemoveAll() error {
+	if d.form == nil {
+		return nil
+	}
+
+	return d.form.RemoveAll()
+}
+
+func readFiles(form *multipart.Form, f *formField, fv reflect.Value) error {
+	files := form.File[f.Name]
+
+	switch fv.Type() {
+	case fileHeaderSliceType:
+		if len(files) > 0 {
+			fv.Set(reflect.ValueOf(files))
+		}
+
+		return nil
+	case fileHeaderType:
+		if len(files) > 0 {
+			fv.Set(reflect.ValueOf(files[0]))
+		}
+
+		return nil
+	case bytesType:
+		if len(files) == 0 {
+			if values := form.Value[f.Name]; len(values) > 0 {
+				fv.SetBytes([]byte(values[0]))
+			}
+
+			return nil
+		}
+
+		file, err := files[0].Open()
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return err
+		}
+
+		fv.SetBytes(content)
+		return nil
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	if fv.Kind() != reflect.Interface {
+		return fmt.Errorf("cannot decode a file into %s; use an interface type or *multipart.FileHeader", fv.Type())
+	}
+
+	file, err := files[0].Open()
+	if err != nil {
+		return err
+	}
+
+	if !reflect.TypeOf(file).AssignableTo(fv.Type()) {
+		file.Close()
+		return fmt.Errorf("%T is not assignable to %s", file, fv.Type())
+	}
+
+	fv.Set(reflect.ValueOf(file))
+	return nil
+}
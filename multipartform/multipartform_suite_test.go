@@ -0,0 +1,13 @@
+package multipartform_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMultipartform(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Multipartform Suite")
+}
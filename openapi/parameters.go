@@ -0,0 +1,200 @@
+package openapi // import "go.gideaworx.io/go-encoding/openapi"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+	"gopkg.in/yaml.v2"
+)
+
+// Parameter is an OpenAPI 3 parameter object.
+type Parameter struct {
+	Name     string  `json:"name" yaml:"name"`
+	In       string  `json:"in" yaml:"in"`
+	Required bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Style    string  `json:"style,omitempty" yaml:"style,omitempty"`
+	Explode  *bool   `json:"explode,omitempty" yaml:"explode,omitempty"`
+	Schema   *Schema `json:"schema" yaml:"schema"`
+	// Join is the x-join extension. It holds the separator of a joined list when it is not one OpenAPI has a style for.
+	Join string `json:"x-join,omitempty" yaml:"x-join,omitempty"`
+}
+
+// Schema is the subset of the OpenAPI 3 schema object needed to describe parameters.
+type Schema struct {
+	Type     string   `json:"type" yaml:"type"`
+	Format   string   `json:"format,omitempty" yaml:"format,omitempty"`
+	Pattern  string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Items    *Schema  `json:"items,omitempty" yaml:"items,omitempty"`
+	Enum     []any    `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Default  any      `json:"default,omitempty" yaml:"default,omitempty"`
+	Minimum  *float64 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	MinItems *int     `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems *int     `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+}
+
+// Parameters describes the parameters of the struct type t (or a pointer to one) as OpenAPI parameter objects. It
+// walks the same urlvalues.Fields metadata that urlvalues.MarshalURLValues and urlvalues.UnmarshalURLValues use, so
+// the documentation follows the wire format:
+//
+//   - fields with a "url" tag (or no tag) are in=query, and fields with a "path" tag are in=path and always required
+//   - the schema type and format follow the field's kind and "urlformat" tag, e.g. time.Time is a date-time string,
+//     a bool with urlformat:"int" is an integer enum of 0 and 1, and a time.Duration with urlformat:"int,ms" is an
+//     integer
+//   - slices and arrays are arrays. Repeated parameters are style=form with explode=true. Joined lists are
+//     style=form (","), spaceDelimited (" ") or pipeDelimited ("|") with explode=false; any other separator is kept
+//     in the x-join extension
+//   - the required and default='...' tag options set required and the schema's default. As in decoding, a parameter
+//     with a default is not required, even if its tag says so
+func Parameters(t reflect.Type) ([]Parameter, error) {
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	fields, err := urlvalues.Fields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]Parameter, 0, len(fields))
+	for _, f := range fields {
+		p, err := parameter(f)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.StructField.Name, err)
+		}
+
+		params = append(params, p)
+	}
+
+	return params, nil
+}
+
+// ParametersJSON returns the parameters of t as a JSON array.
+func ParametersJSON(t reflect.Type) ([]byte, error) {
+	params, err := Parameters(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(params, "", "  ")
+}
+
+// ParametersYAML returns the parameters of t as a YAML sequence.
+func ParametersYAML(t reflect.Type) ([]byte, error) {
+	params, err := Parameters(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(params)
+}
+
+func parameter(f urlvalues.Field) (Parameter, error) {
+	p := Parameter{
+		Name:     f.Name,
+		In:       f.In,
+		Required: (f.Tag.Required && !f.Tag.HasDefault) || f.In == urlvalues.InPath,
+	}
+
+	t := f.StructField.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	schema, err := SchemaFor(t, f.Format)
+	if err != nil {
+		return p, err
+	}
+	p.Schema = schema
+
+	if schema.Type == "array" && f.In == urlvalues.InQuery {
+		explode := f.Tag.Join == ""
+		p.Style = "form"
+		p.Explode = &explode
+
+		switch f.Tag.Join {
+		case "", ",":
+		case " ":
+			p.Style = "spaceDelimited"
+		case "|":
+			p.Style = "pipeDelimited"
+		default:
+			p.Join = f.Tag.Join
+		}
+	}
+
+	if f.Tag.HasDefault {
+		if schema.Default, err = DefaultValue(f.Tag.Default, t, f.Format, f.Tag.Join); err != nil {
+			return p, fmt.Errorf("invalid default: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// DefaultValue parses s, the text of a default option or any other parameter value of type t, and returns it as a
+// value that marshals to the JSON or YAML type SchemaFor declares for t. Lists are split on join, or "," if it is
+// empty.
+func DefaultValue(s string, t reflect.Type, format string, join string) (any, error) {
+	if (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && !urlvalues.HasCodec(t) {
+		if join == "" {
+			join = ","
+		}
+
+		var values []any
+		for _, part := range strings.Split(s, join) {
+			v, err := DefaultValue(part, t.Elem(), format, "")
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, v)
+		}
+
+		return values, nil
+	}
+
+	v, err := urlvalues.ParseValue(s, t, format)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := SchemaFor(t, format)
+	if err != nil {
+		return nil, err
+	}
+
+	switch schema.Type {
+	case "boolean":
+		return v.Bool(), nil
+	case "integer":
+		if v.Kind() == reflect.Bool {
+			// bools formatted as integers
+			return map[bool]int{false: 0, true: 1}[v.Bool()], nil
+		}
+
+		if t == monthType || t == weekdayType {
+			// names are accepted even when numbers are produced
+			return v.Int(), nil
+		}
+
+		// parse the tag text rather than v, so durations keep their unit
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, nil
+		}
+
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return u, nil
+		}
+	case "number":
+		return v.Float(), nil
+	}
+
+	return s, nil
+}
+
+var errUnsupported = errors.New("unsupported type")
@@ -0,0 +1,161 @@
+package openapi_test
+
+import (
+	"math/big"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/openapi"
+)
+
+type listQuery struct {
+	ID       string        `path:"id"`
+	Limit    int           `url:"limit,required,default=20"`
+	Since    time.Time     `url:"since,omitempty"`
+	Day      time.Time     `url:"day" urlformat:"2006-01-02"`
+	Timeout  time.Duration `url:"timeout" urlformat:"int,ms"`
+	Debug    bool          `url:"debug" urlformat:"int"`
+	Tags     []string      `url:"tag,required"`
+	Sizes    []uint8       `url:"sizes,join='|'"`
+	Fields   []string      `url:"fields,join=','"`
+	Words    []string      `url:"words,join=' '"`
+	Pairs    []int         `url:"pairs,join=';',default='1;2'"`
+	Ratio    *float32      `url:"ratio"`
+	Corners  [2]int        `url:"corners"`
+	Skipped  string        `url:"-"`
+	internal string
+}
+
+var _ = Describe("Parameters", func() {
+	It("describes query and path parameters", func() {
+		params, err := openapi.Parameters(reflect.TypeOf(&listQuery{}))
+		Expect(err).NotTo(HaveOccurred())
+
+		names := make([]string, 0, len(params))
+		for _, p := range params {
+			names = append(names, p.In+":"+p.Name)
+		}
+		Expect(names).To(Equal([]string{
+			"path:id", "query:limit", "query:since", "query:day", "query:timeout", "query:debug", "query:tag",
+			"query:sizes", "query:fields", "query:words", "query:pairs", "query:ratio", "query:corners",
+		}))
+	})
+
+	It("generates JSON", func() {
+		b, err := openapi.ParametersJSON(reflect.TypeOf(listQuery{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`[
+			{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}},
+			{"name": "limit", "in": "query", "schema": {"type": "integer", "format": "int64", "default": 20}},
+			{"name": "since", "in": "query", "schema": {"type": "string", "format": "date-time"}},
+			{"name": "day", "in": "query", "schema": {"type": "string", "format": "date"}},
+			{"name": "timeout", "in": "query", "schema": {"type": "integer", "format": "int64"}},
+			{"name": "debug", "in": "query", "schema": {"type": "integer", "enum": [0, 1]}},
+			{"name": "tag", "in": "query", "required": true, "style": "form", "explode": true,
+				"schema": {"type": "array", "items": {"type": "string"}}},
+			{"name": "sizes", "in": "query", "style": "pipeDelimited", "explode": false,
+				"schema": {"type": "array", "items": {"type": "integer", "format": "int32", "minimum": 0}}},
+			{"name": "fields", "in": "query", "style": "form", "explode": false,
+				"schema": {"type": "array", "items": {"type": "string"}}},
+			{"name": "words", "in": "query", "style": "spaceDelimited", "explode": false,
+				"schema": {"type": "array", "items": {"type": "string"}}},
+			{"name": "pairs", "in": "query", "style": "form", "explode": false, "x-join": ";",
+				"schema": {"type": "array", "items": {"type": "integer", "format": "int64"}, "default": [1, 2]}},
+			{"name": "ratio", "in": "query", "schema": {"type": "number", "format": "float"}},
+			{"name": "corners", "in": "query", "style": "form", "explode": true,
+				"schema": {"type": "array", "items": {"type": "integer", "format": "int64"}, "minItems": 2, "maxItems": 2}}
+		]`))
+	})
+
+	It("describes standard library value types", func() {
+		type schedule struct {
+			Month  time.Month   `url:"month"`
+			Day    time.Weekday `url:"day,default=tuesday" urlformat:"name"`
+			Server netip.Addr   `url:"server"`
+			Ratio  *big.Rat     `url:"ratio"`
+		}
+
+		b, err := openapi.ParametersJSON(reflect.TypeOf(schedule{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`[
+			{"name": "month", "in": "query", "schema": {"type": "integer", "format": "int64"}},
+			{"name": "day", "in": "query", "schema": {"type": "string", "default": "tuesday",
+				"enum": ["Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"]}},
+			{"name": "server", "in": "query", "schema": {"type": "string"}},
+			{"name": "ratio", "in": "query", "schema": {"type": "string"}}
+		]`))
+	})
+
+	It("describes Go durations with a pattern rather than the ISO 8601 duration format", func() {
+		schema, err := openapi.SchemaFor(reflect.TypeOf(time.Duration(0)), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schema.Format).To(BeEmpty())
+
+		re := regexp.MustCompile(schema.Pattern)
+		for _, d := range []string{"1h30m", "-1.5s", "300ms", "2µs", "0"} {
+			_, err := time.ParseDuration(d)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(re.MatchString(d)).To(BeTrue(), d)
+		}
+
+		Expect(re.MatchString("PT1H30M")).To(BeFalse())
+		Expect(re.MatchString("90")).To(BeFalse())
+	})
+
+	It("generates YAML", func() {
+		type pageQuery struct {
+			Page  int      `url:"page,default=1"`
+			Sort  []string `url:"sort,join=','"`
+			Draft bool     `url:"draft"`
+		}
+
+		b, err := openapi.ParametersYAML(reflect.TypeOf(pageQuery{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`- name: page
+  in: query
+  schema:
+    type: integer
+    format: int64
+    default: 1
+- name: sort
+  in: query
+  style: form
+  explode: false
+  schema:
+    type: array
+    items:
+      type: string
+- name: draft
+  in: query
+  schema:
+    type: boolean
+`))
+	})
+
+	It("rejects unsupported field types", func() {
+		type badQuery struct {
+			Callback func() `url:"callback"`
+		}
+
+		_, err := openapi.Parameters(reflect.TypeOf(badQuery{}))
+		Expect(err).To(MatchError(ContainSubstring("field Callback")))
+	})
+
+	It("rejects invalid defaults", func() {
+		type badDefault struct {
+			Limit int `url:"limit,default=lots"`
+		}
+
+		_, err := openapi.Parameters(reflect.TypeOf(badDefault{}))
+		Expect(err).To(MatchError(ContainSubstring("invalid default")))
+	})
+
+	It("rejects non-struct types", func() {
+		_, err := openapi.Parameters(reflect.TypeOf(42))
+		Expect(err).To(HaveOccurred())
+	})
+})
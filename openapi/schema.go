@@ -0,0 +1,126 @@
+package openapi // import "go.gideaworx.io/go-encoding/openapi"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	monthType    = reflect.TypeOf(time.Month(0))
+	weekdayType  = reflect.TypeOf(time.Weekday(0))
+	errType      = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// durationPattern matches the text time.ParseDuration accepts, such as "1h30m" or "-1.5s". Durations are described by
+// it rather than by the "duration" format, which means an ISO 8601 duration such as PT1H30M.
+const durationPattern = `^[-+]?(0|((\d+(\.\d*)?|\.\d+)(ns|us|µs|μs|ms|s|m|h))+)$`
+
+// SchemaFor returns the schema of a single parameter value of type t, given the value of its "urlformat" tag.
+func SchemaFor(t reflect.Type, format string) (*Schema, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if urlvalues.HasCodec(t) {
+		// the codec decides the text, so the most that can be said is that it is a string
+		return &Schema{Type: "string"}, nil
+	}
+
+	switch t {
+	case timeType:
+		switch format {
+		case "", time.RFC3339:
+			return &Schema{Type: "string", Format: "date-time"}, nil
+		case time.DateOnly:
+			return &Schema{Type: "string", Format: "date"}, nil
+		}
+
+		return &Schema{Type: "string"}, nil
+	case durationType:
+		if strings.EqualFold(strings.Split(format, ",")[0], "int") {
+			return &Schema{Type: "integer", Format: "int64"}, nil
+		}
+
+		return &Schema{Type: "string", Pattern: durationPattern}, nil
+	case monthType, weekdayType:
+		if strings.EqualFold(format, "name") {
+			return calendarSchema(t), nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return boolSchema(format), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}, nil
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}, nil
+	case reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32", Minimum: new(float64)}, nil
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Minimum: new(float64)}, nil
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}, nil
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}, nil
+	case reflect.Complex64, reflect.Complex128, reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := SchemaFor(t.Elem(), format)
+		if err != nil {
+			return nil, err
+		}
+
+		schema := &Schema{Type: "array", Items: items}
+		if t.Kind() == reflect.Array {
+			n := t.Len()
+			schema.MinItems, schema.MaxItems = &n, &n
+		}
+
+		return schema, nil
+	}
+
+	if errType.AssignableTo(t) {
+		return &Schema{Type: "string"}, nil
+	}
+
+	return nil, fmt.Errorf("%w %s", errUnsupported, t)
+}
+
+// calendarSchema enumerates the names of a time.Month or time.Weekday, for fields formatted by name.
+func calendarSchema(t reflect.Type) *Schema {
+	first, last := int(time.January), int(time.December)
+	if t == weekdayType {
+		first, last = int(time.Sunday), int(time.Saturday)
+	}
+
+	schema := &Schema{Type: "string"}
+	for n := first; n <= last; n++ {
+		schema.Enum = append(schema.Enum, fmt.Sprint(reflect.ValueOf(n).Convert(t).Interface()))
+	}
+
+	return schema
+}
+
+func boolSchema(format string) *Schema {
+	switch strings.ToLower(format) {
+	case "int":
+		return &Schema{Type: "integer", Enum: []any{0, 1}}
+	case "shortlower":
+		return &Schema{Type: "string", Enum: []any{"t", "f"}}
+	case "short":
+		return &Schema{Type: "string", Enum: []any{"T", "F"}}
+	case "upper":
+		return &Schema{Type: "string", Enum: []any{"TRUE", "FALSE"}}
+	case "camel":
+		return &Schema{Type: "string", Enum: []any{"True", "False"}}
+	}
+
+	return &Schema{Type: "boolean"}
+}
@@ -0,0 +1,65 @@
+package signing // import "go.gideaworx.io/go-encoding/signing"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/url"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// HMACSigner signs the canonical form of a set of parameters with an HMAC. It covers the common "sort the query,
+// HMAC it with a shared secret, send the hex digest" schemes; OAuth1 and SigV4 cover the schemes that sign more than
+// the parameters.
+type HMACSigner struct {
+	// Key is the shared secret.
+	Key []byte
+	// Canonicalizer builds the string that is signed. If nil, SortedRFC3986 is used.
+	Canonicalizer Canonicalizer
+	// Hash is the hash the HMAC is built on. If nil, sha256.New is used.
+	Hash func() hash.Hash
+}
+
+// Sign returns the hex encoded HMAC of the canonical form of values.
+func (s HMACSigner) Sign(values url.Values) string {
+	return hex.EncodeToString(s.mac(values))
+}
+
+// SignStruct marshals a with urlvalues.MarshalURLValues and signs the result.
+func (s HMACSigner) SignStruct(a any) (string, error) {
+	values, err := urlvalues.MarshalURLValues(a)
+	if err != nil {
+		return "", err
+	}
+
+	return s.Sign(values), nil
+}
+
+// Verify reports whether signature is the hex encoded HMAC of the canonical form of values. The comparison is done in
+// constant time.
+func (s HMACSigner) Verify(values url.Values, signature string) bool {
+	decoded, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(decoded, s.mac(values))
+}
+
+func (s HMACSigner) mac(values url.Values) []byte {
+	c := s.Canonicalizer
+	if c == nil {
+		c = SortedRFC3986
+	}
+
+	h := s.Hash
+	if h == nil {
+		h = sha256.New
+	}
+
+	mac := hmac.New(h, s.Key)
+	mac.Write([]byte(c.Canonicalize(values)))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,76 @@
+package signing // import "go.gideaworx.io/go-encoding/signing"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// OAuth1BaseString builds the OAuth 1.0a signature base string (RFC 5849, section 3.4.1) of a request. rawURL is the
+// request URL; its query parameters are merged with params, which should hold the oauth_* protocol parameters and any
+// application/x-www-form-urlencoded body parameters. The scheme and host are lowercased, default ports are dropped,
+// and the fragment is ignored.
+func OAuth1BaseString(method, rawURL string, params url.Values) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return "", errors.New("url must be absolute")
+	}
+
+	all := u.Query()
+	for k, vs := range params {
+		all[k] = append(all[k], vs...)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	escape := urlvalues.RFC3986Escaping.Escape
+	return strings.Join([]string{
+		escape(strings.ToUpper(method)),
+		escape(scheme + "://" + host + path),
+		escape(OAuth1.Canonicalize(all)),
+	}, "&"), nil
+}
+
+// OAuth1Sign returns the base64 encoded HMAC-SHA1 signature of baseString, using the consumer secret and token secret
+// as the key (RFC 5849, section 3.4.2).
+func OAuth1Sign(baseString, consumerSecret, tokenSecret string) string {
+	mac := hmac.New(sha1.New, oauth1Key(consumerSecret, tokenSecret))
+	mac.Write([]byte(baseString))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// OAuth1Verify reports whether signature is the HMAC-SHA1 signature of baseString. The comparison is done in constant
+// time.
+func OAuth1Verify(baseString, consumerSecret, tokenSecret, signature string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha1.New, oauth1Key(consumerSecret, tokenSecret))
+	mac.Write([]byte(baseString))
+	return hmac.Equal(decoded, mac.Sum(nil))
+}
+
+func oauth1Key(consumerSecret, tokenSecret string) []byte {
+	escape := urlvalues.RFC3986Escaping.Escape
+	return []byte(escape(consumerSecret) + "&" + escape(tokenSecret))
+}
@@ -0,0 +1,181 @@
+package signing_test
+
+import (
+	"crypto/sha1"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/signing"
+)
+
+var _ = Describe("Signing", func() {
+	Describe("Canonicalization", func() {
+		values := url.Values{
+			"b":     {"2", "1"},
+			"a key": {"x y~z"},
+			"c":     {""},
+		}
+
+		It("sorts keys and keeps value order for SortedRFC3986", func() {
+			Expect(signing.SortedRFC3986.Canonicalize(values)).To(Equal("a%20key=x%20y~z&b=2&b=1&c="))
+		})
+
+		It("sorts values too for AWSSigV4", func() {
+			Expect(signing.AWSSigV4.Canonicalize(values)).To(Equal("a%20key=x%20y~z&b=1&b=2&c="))
+		})
+
+		It("canonicalizes structs", func() {
+			s, err := signing.CanonicalQuery(struct {
+				Param2 string `url:"Param2"`
+				Param1 string `url:"Param1"`
+			}{"value2", "value1"}, signing.AWSSigV4)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal("Param1=value1&Param2=value2"))
+		})
+
+		It("accepts custom canonicalizers", func() {
+			c := signing.CanonicalizerFunc(func(v url.Values) string { return v.Encode() })
+			Expect(c.Canonicalize(url.Values{"a": {"b c"}})).To(Equal("a=b+c"))
+		})
+
+		// RFC 5849, section 3.4.1.3.2
+		It("normalizes OAuth 1.0a parameters", func() {
+			params := url.Values{}
+			for _, query := range []string{
+				"b5=%3D%253D&a3=a&c%40=&a2=r%20b",
+				"c2&a3=2+q",
+				"oauth_consumer_key=9djdj82h48djs9d2&oauth_token=kkk9d7dh3k39sjv7&oauth_signature_method=HMAC-SHA1" +
+					"&oauth_timestamp=137131201&oauth_nonce=7d8f3e4a&oauth_signature=djosJKDKJSD8743243%2Fjdk33klY%3D",
+			} {
+				parsed, err := url.ParseQuery(query)
+				Expect(err).NotTo(HaveOccurred())
+				for k, v := range parsed {
+					params[k] = append(params[k], v...)
+				}
+			}
+
+			Expect(signing.OAuth1.Canonicalize(params)).To(Equal(
+				"a2=r%20b&a3=2%20q&a3=a&b5=%3D%253D&c%40=&c2=&oauth_consumer_key=9djdj82h48djs9d2&oauth_nonce=7d8f3e4a" +
+					"&oauth_signature_method=HMAC-SHA1&oauth_timestamp=137131201&oauth_token=kkk9d7dh3k39sjv7"))
+		})
+	})
+
+	Describe("HMACSigner", func() {
+		It("signs and verifies", func() {
+			s := signing.HMACSigner{Key: []byte("secret")}
+			values := url.Values{"b": {"2"}, "a": {"1"}}
+
+			sig := s.Sign(values)
+			Expect(sig).To(HaveLen(64))
+			Expect(s.Verify(values, sig)).To(BeTrue())
+
+			structSig, err := s.SignStruct(struct {
+				B int `url:"b"`
+				A int `url:"a"`
+			}{2, 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(structSig).To(Equal(sig))
+
+			values.Set("a", "tampered")
+			Expect(s.Verify(values, sig)).To(BeFalse())
+			Expect(s.Verify(values, "not hex")).To(BeFalse())
+		})
+
+		It("uses the configured hash and canonicalizer", func() {
+			s := signing.HMACSigner{Key: []byte("secret"), Hash: sha1.New, Canonicalizer: signing.AWSSigV4}
+			Expect(s.Sign(url.Values{"a": {"1"}})).To(HaveLen(40))
+		})
+	})
+
+	// OAuth Core 1.0, appendix A.5
+	Describe("OAuth 1.0a", func() {
+		params := url.Values{
+			"oauth_consumer_key":     {"dpf43f3p2l4k3l03"},
+			"oauth_token":            {"nnch734d00sl2jdk"},
+			"oauth_signature_method": {"HMAC-SHA1"},
+			"oauth_timestamp":        {"1191242096"},
+			"oauth_nonce":            {"kllo9940pd9333jh"},
+			"oauth_version":          {"1.0"},
+		}
+
+		It("matches the published base string and signature", func() {
+			base, err := signing.OAuth1BaseString("get", "HTTP://Photos.Example.net:80/photos?file=vacation.jpg&size=original", params)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(base).To(Equal("GET&http%3A%2F%2Fphotos.example.net%2Fphotos&file%3Dvacation.jpg%26" +
+				"oauth_consumer_key%3Ddpf43f3p2l4k3l03%26oauth_nonce%3Dkllo9940pd9333jh%26oauth_signature_method%3DHMAC-SHA1%26" +
+				"oauth_timestamp%3D1191242096%26oauth_token%3Dnnch734d00sl2jdk%26oauth_version%3D1.0%26size%3Doriginal"))
+
+			sig := signing.OAuth1Sign(base, "kd94hf93k423kf44", "pfkkdhi9sl3r4s00")
+			Expect(sig).To(Equal("tR3+Ty81lMeYAr/Fid0kMTYa/WM="))
+			Expect(signing.OAuth1Verify(base, "kd94hf93k423kf44", "pfkkdhi9sl3r4s00", sig)).To(BeTrue())
+			Expect(signing.OAuth1Verify(base, "wrong", "pfkkdhi9sl3r4s00", sig)).To(BeFalse())
+		})
+
+		It("requires an absolute url", func() {
+			_, err := signing.OAuth1BaseString("GET", "/photos", params)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	// AWS General Reference, "Examples of the complete Signature Version 4 signing process"
+	Describe("AWS SigV4", func() {
+		s := signing.SigV4{
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+			Region:          "us-east-1",
+			Service:         "iam",
+		}
+
+		r := signing.SigV4Request{
+			Method: http.MethodGet,
+			Path:   "/",
+			Query:  url.Values{"Version": {"2010-05-08"}, "Action": {"ListUsers"}},
+			Header: http.Header{
+				"Content-Type": {"application/x-www-form-urlencoded; charset=utf-8"},
+				"Host":         {"iam.amazonaws.com"},
+				"X-Amz-Date":   {"20150830T123600Z"},
+			},
+			Time: time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC),
+		}
+
+		It("matches the published canonical request", func() {
+			canonical, signed := s.CanonicalRequest(r)
+			Expect(signed).To(Equal("content-type;host;x-amz-date"))
+			Expect(canonical).To(Equal(strings.Join([]string{
+				"GET",
+				"/",
+				"Action=ListUsers&Version=2010-05-08",
+				"content-type:application/x-www-form-urlencoded; charset=utf-8",
+				"host:iam.amazonaws.com",
+				"x-amz-date:20150830T123600Z",
+				"",
+				"content-type;host;x-amz-date",
+				"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			}, "\n")))
+		})
+
+		It("matches the published string to sign and signature", func() {
+			Expect(s.StringToSign(r)).To(Equal(strings.Join([]string{
+				"AWS4-HMAC-SHA256",
+				"20150830T123600Z",
+				"20150830/us-east-1/iam/aws4_request",
+				"f536975d06c0309214f805bb90ccff089219ecd68b2577efef23edd43b7e1a59",
+			}, "\n")))
+
+			Expect(s.Sign(r)).To(Equal("5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"))
+			Expect(s.Authorization(r)).To(Equal("AWS4-HMAC-SHA256 " +
+				"Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+				"SignedHeaders=content-type;host;x-amz-date, " +
+				"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"))
+		})
+
+		It("verifies signatures", func() {
+			Expect(s.Verify(r, "5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7")).To(BeTrue())
+			Expect(s.Verify(r, "5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d8")).To(BeFalse())
+		})
+	})
+})
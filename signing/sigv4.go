@@ -0,0 +1,147 @@
+package signing // import "go.gideaworx.io/go-encoding/signing"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat = "20060102T150405Z"
+	sigV4DateFormat = "20060102"
+)
+
+// SigV4 computes AWS Signature Version 4 signatures. Only the query string and the signed headers of a request
+// are canonicalized here; the caller supplies the hex encoded SHA-256 hash of the payload.
+type SigV4 struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Service         string
+}
+
+// SigV4Request describes the parts of a request that are signed.
+type SigV4Request struct {
+	Method string
+	// Path is the URI-encoded absolute path of the request. An empty path is signed as "/".
+	Path  string
+	Query url.Values
+	// Header holds the headers to sign. Host must be included.
+	Header http.Header
+	// PayloadHash is the hex encoded SHA-256 hash of the body. If empty, the hash of an empty body is used.
+	PayloadHash string
+	Time        time.Time
+}
+
+// CanonicalRequest returns the canonical request string for r, along with the ';' separated list of signed headers.
+func (s SigV4) CanonicalRequest(r SigV4Request) (canonical string, signedHeaders string) {
+	path := r.Path
+	if path == "" {
+		path = "/"
+	}
+
+	payloadHash := r.PayloadHash
+	if payloadHash == "" {
+		empty := sha256.Sum256(nil)
+		payloadHash = hex.EncodeToString(empty[:])
+	}
+
+	names := make([]string, 0, len(r.Header))
+	headers := make(map[string]string, len(r.Header))
+	for k, vs := range r.Header {
+		name := strings.ToLower(k)
+		trimmed := make([]string, 0, len(vs))
+		for _, v := range vs {
+			trimmed = append(trimmed, strings.Join(strings.Fields(v), " "))
+		}
+
+		if _, ok := headers[name]; !ok {
+			names = append(names, name)
+		}
+		headers[name] = strings.Join(trimmed, ",")
+	}
+	slices.Sort(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(name)
+		sb.WriteByte(':')
+		sb.WriteString(headers[name])
+		sb.WriteByte('\n')
+	}
+
+	signedHeaders = strings.Join(names, ";")
+	canonical = strings.Join([]string{
+		strings.ToUpper(r.Method),
+		path,
+		AWSSigV4.Canonicalize(r.Query),
+		sb.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	return canonical, signedHeaders
+}
+
+// StringToSign returns the string that is signed for r.
+func (s SigV4) StringToSign(r SigV4Request) string {
+	canonical, _ := s.CanonicalRequest(r)
+	hashed := sha256.Sum256([]byte(canonical))
+
+	return strings.Join([]string{
+		sigV4Algorithm,
+		r.Time.UTC().Format(sigV4TimeFormat),
+		s.scope(r.Time),
+		hex.EncodeToString(hashed[:]),
+	}, "\n")
+}
+
+// Sign returns the hex encoded signature of r.
+func (s SigV4) Sign(r SigV4Request) string {
+	return hex.EncodeToString(s.sign(r))
+}
+
+// Authorization returns the value of the Authorization header carrying the signature of r.
+func (s SigV4) Authorization(r SigV4Request) string {
+	_, signedHeaders := s.CanonicalRequest(r)
+	return fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, s.AccessKeyID, s.scope(r.Time), signedHeaders, s.Sign(r))
+}
+
+// Verify reports whether signature is the hex encoded signature of r. The comparison is done in constant time.
+func (s SigV4) Verify(r SigV4Request, signature string) bool {
+	decoded, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(decoded, s.sign(r))
+}
+
+func (s SigV4) sign(r SigV4Request) []byte {
+	date := r.Time.UTC().Format(sigV4DateFormat)
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), date)
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, s.Service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return hmacSHA256(key, s.StringToSign(r))
+}
+
+func (s SigV4) scope(t time.Time) string {
+	return strings.Join([]string{t.UTC().Format(sigV4DateFormat), s.Region, s.Service, "aws4_request"}, "/")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
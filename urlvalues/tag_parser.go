@@ -8,9 +8,16 @@ import (
 )
 
 type urlValueTag struct {
-	name       string
-	omitEmpty  bool
-	joinString string
+	name         string
+	aliases      []string
+	omitEmpty    bool
+	joinString   string
+	required     bool
+	defaultValue string
+	hasDefault   bool
+	inline       bool
+	omitZero     bool
+	omitNil      bool
 }
 
 func strSliceCheck(expectedValue string) func(string) bool {
@@ -25,13 +32,22 @@ func parseTag(tag string) (*urlValueTag, error) {
 	}
 
 	parts := strings.Split(tag, ",")
+	names := strings.Split(parts[0], "|")
 	t := &urlValueTag{
-		name: parts[0],
+		name: names[0],
+	}
+
+	for _, alias := range names[1:] {
+		if alias != "" {
+			t.aliases = append(t.aliases, alias)
+		}
 	}
 
 	joinStartIndex := slices.IndexFunc(parts, strSliceCheck("join='"))
 	joinEndIndex := -1
-	if joinStartIndex > 0 {
+	if joinStartIndex > 0 && isClosedQuote(strings.TrimPrefix(parts[joinStartIndex], "join='")) {
+		joinEndIndex = joinStartIndex
+	} else if joinStartIndex > 0 {
 		joinEndIndex = joinStartIndex + 1 + slices.IndexFunc(parts[joinStartIndex+1:], func(s string) bool {
 			return strings.HasSuffix(s, "'")
 		})
@@ -62,5 +78,80 @@ func parseTag(tag string) (*urlValueTag, error) {
 		t.omitEmpty = (omitIndex > 0)
 	}
 
+	t.defaultValue, t.hasDefault = quotedOption(tag, "default")
+	for _, option := range strings.Split(stripQuoted(tag), ",")[1:] {
+		switch strings.ToLower(strings.TrimSpace(option)) {
+		case "required":
+			t.required = true
+		case "inline":
+			t.inline = true
+		case "omitzero":
+			t.omitZero = true
+		case "omitnil":
+			t.omitNil = true
+		}
+	}
+
 	return t, nil
 }
+
+// isClosedQuote reports whether the remainder of a quoted option ends its quote, e.g. the "X'" of "join='X'".
+func isClosedQuote(rest string) bool {
+	return strings.HasSuffix(rest, "'") && rest != ""
+}
+
+// quotedOption finds the value of the key=value option in tag. Values wrapped in single quotes may contain commas.
+func quotedOption(tag, key string) (string, bool) {
+	prefix := "," + key + "="
+	idx := strings.Index(blankQuoted(tag), prefix)
+	if idx < 0 {
+		return "", false
+	}
+
+	value := tag[idx+len(prefix):]
+	if strings.HasPrefix(value, "'") {
+		if end := strings.Index(value[1:], "'"); end >= 0 {
+			return value[1 : end+1], true
+		}
+	}
+
+	value, _, _ = strings.Cut(value, ",")
+	return value, true
+}
+
+// stripQuoted removes every single-quoted section of tag so that the remaining options can be split on commas.
+func stripQuoted(tag string) string {
+	var sb strings.Builder
+	inQuote := false
+	for _, r := range tag {
+		if r == '\'' {
+			inQuote = !inQuote
+			continue
+		}
+
+		if !inQuote {
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+// blankQuoted replaces the content of every single-quoted section of tag with spaces, keeping offsets intact, so
+// that an option name inside another option's quoted value is never matched.
+func blankQuoted(tag string) string {
+	b := []byte(tag)
+	inQuote := false
+	for i, c := range b {
+		if c == '\'' {
+			inQuote = !inQuote
+			continue
+		}
+
+		if inQuote {
+			b[i] = ' '
+		}
+	}
+
+	return string(b)
+}
@@ -0,0 +1,68 @@
+package urlvalues_test
+
+import (
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var _ = Describe("Aliases", func() {
+	type listing struct {
+		Limit  int      `url:"limit|page_size|pagesize,omitempty"`
+		Fields []string `url:"fields|f,join=','"`
+	}
+
+	It("parses aliases from the tag", func() {
+		tag, err := urlvalues.ParseTag("limit|page_size||pagesize,omitempty")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tag.Name).To(Equal("limit"))
+		Expect(tag.Aliases).To(Equal([]string{"page_size", "pagesize"}))
+		Expect(tag.OmitEmpty).To(BeTrue())
+	})
+
+	It("marshals the first name", func() {
+		v, err := urlvalues.MarshalURLValues(listing{Limit: 10, Fields: []string{"a", "b"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"limit": {"10"}, "fields": {"a,b"}}))
+	})
+
+	DescribeTable("accepts any name on unmarshal",
+		func(values url.Values, expected listing) {
+			var l listing
+			Expect(urlvalues.UnmarshalURLValues(values, &l)).To(Succeed())
+			Expect(l).To(Equal(expected))
+		},
+		Entry("name", url.Values{"limit": {"1"}}, listing{Limit: 1}),
+		Entry("first alias", url.Values{"page_size": {"2"}}, listing{Limit: 2}),
+		Entry("second alias", url.Values{"pagesize": {"3"}, "f": {"x,y"}}, listing{Limit: 3, Fields: []string{"x", "y"}}),
+		Entry("name over alias", url.Values{"pagesize": {"3"}, "limit": {"4"}}, listing{Limit: 4}),
+		Entry("earlier alias over later", url.Values{"pagesize": {"3"}, "page_size": {"5"}}, listing{Limit: 5}),
+	)
+
+	It("reports deprecated names to the hook", func() {
+		var used [][2]string
+		d := urlvalues.NewDecoder(strings.NewReader("page_size=20&fields=a"))
+		d.SetAliasHook(func(name, alias string) {
+			used = append(used, [2]string{name, alias})
+		})
+
+		var l listing
+		Expect(d.Decode(&l)).To(Succeed())
+		Expect(l).To(Equal(listing{Limit: 20, Fields: []string{"a"}}))
+		Expect(used).To(Equal([][2]string{{"limit", "page_size"}}))
+	})
+
+	It("matches aliases with key normalization", func() {
+		var l listing
+		d := urlvalues.NewDecoder(nil)
+		d.SetKeyMatching(urlvalues.CaseInsensitive)
+		Expect(d.DecodeValues(url.Values{"PageSize": {"7"}}, &l)).To(Succeed())
+		Expect(l.Limit).To(Equal(7))
+
+		err := d.DecodeValues(url.Values{"LIMIT": {"1"}, "page_size": {"2"}}, &l)
+		Expect(err).To(MatchError(ContainSubstring(`both match "limit"`)))
+	})
+})
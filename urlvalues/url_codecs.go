@@ -0,0 +1,89 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// codec is a registered pair of conversions for a single type, wrapped to work on reflect values.
+type codec struct {
+	encode func(reflect.Value) (string, error)
+	decode func(string) (reflect.Value, error)
+}
+
+var codecs sync.Map // map[reflect.Type]codec
+
+// RegisterCodec registers the conversions used for values of type T, which is useful for third party types such as
+// UUIDs and decimals that cannot be given methods. The codec is consulted before any built-in rule, so it also
+// overrides the handling of types the package already knows, and it applies to struct fields, slice and array
+// elements, pointers to T and map values alike. A T whose kind is array or slice, such as a [16]byte UUID, is treated
+// as a single value rather than a list. Format tags are not passed to the codec.
+//
+//	urlvalues.RegisterCodec(func(id uuid.UUID) (string, error) {
+//		return id.String(), nil
+//	}, uuid.Parse)
+//
+// It is meant to be called from init functions, and panics if either function is nil. Registering T again replaces
+// its codec.
+func RegisterCodec[T any](encode func(T) (string, error), decode func(string) (T, error)) {
+	t := reflect.TypeFor[T]()
+	if encode == nil || decode == nil {
+		panic(fmt.Sprintf("urlvalues: RegisterCodec of %s with a nil function", t))
+	}
+
+	codecs.Store(t, codec{
+		encode: func(v reflect.Value) (string, error) {
+			value, _ := v.Interface().(T)
+			return encode(value)
+		},
+		decode: func(s string) (reflect.Value, error) {
+			value, err := decode(s)
+			if err != nil {
+				return reflect.Zero(t), err
+			}
+
+			return reflect.ValueOf(&value).Elem(), nil
+		},
+	})
+}
+
+// HasCodec reports whether values of t are converted by a codec, either one registered with RegisterCodec or one of
+// the built-in codecs for standard library types such as netip.Addr, net.IP and big.Int.
+func HasCodec(t reflect.Type) bool {
+	_, ok := lookupCodec(t)
+	return ok
+}
+
+func lookupCodec(t reflect.Type) (codec, bool) {
+	if t == nil {
+		return codec{}, false
+	}
+
+	c, ok := codecs.Load(t)
+	if !ok {
+		return codec{}, false
+	}
+
+	return c.(codec), true
+}
+
+// isList reports whether values of t are encoded as one parameter value per element.
+func isList(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && !HasCodec(t)
+}
+
+// stringFromCodec encodes v with the codec registered for its type. The boolean result is false if there is none.
+func stringFromCodec(v reflect.Value) (string, bool, error) {
+	if !v.IsValid() {
+		return "", false, nil
+	}
+
+	c, ok := lookupCodec(v.Type())
+	if !ok {
+		return "", false, nil
+	}
+
+	s, err := c.encode(v)
+	return s, true, err
+}
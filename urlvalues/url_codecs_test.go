@@ -0,0 +1,132 @@
+package urlvalues_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// shortID stands in for third party array-backed identifiers such as UUIDs.
+type shortID [4]byte
+
+// cents stands in for third party decimal types.
+type cents int64
+
+func init() {
+	urlvalues.RegisterCodec(func(id shortID) (string, error) {
+		return hex.EncodeToString(id[:]), nil
+	}, func(s string) (shortID, error) {
+		var id shortID
+		b, err := hex.DecodeString(s)
+		if err != nil || len(b) != len(id) {
+			return id, fmt.Errorf("invalid id %q", s)
+		}
+
+		copy(id[:], b)
+		return id, nil
+	})
+
+	urlvalues.RegisterCodec(func(c cents) (string, error) {
+		if c < 0 {
+			return "", errors.New("negative amount")
+		}
+
+		return fmt.Sprintf("%d.%02d", c/100, c%100), nil
+	}, func(s string) (cents, error) {
+		whole, frac, _ := strings.Cut(s, ".")
+		n, err := strconv.ParseInt(whole+frac, 10, 64)
+		return cents(n), err
+	})
+}
+
+var _ = Describe("Codecs", func() {
+	type order struct {
+		ID      shortID    `url:"id"`
+		Parent  *shortID   `url:"parent"`
+		Items   []shortID  `url:"item"`
+		Total   cents      `url:"total"`
+		Refunds []cents    `url:"refunds,join=';'"`
+		Tip     *cents     `url:"tip"`
+		Other   [2]shortID `url:"other"`
+	}
+
+	parent := shortID{0xca, 0xfe, 0xba, 0xbe}
+	tip := cents(150)
+	o := order{
+		ID:      shortID{0xde, 0xad, 0xbe, 0xef},
+		Parent:  &parent,
+		Items:   []shortID{{1, 2, 3, 4}, {5, 6, 7, 8}},
+		Total:   1234,
+		Refunds: []cents{100, 5},
+		Tip:     &tip,
+		Other:   [2]shortID{{0, 0, 0, 1}, {0, 0, 0, 2}},
+	}
+
+	values := url.Values{
+		"id":      {"deadbeef"},
+		"parent":  {"cafebabe"},
+		"item":    {"01020304", "05060708"},
+		"total":   {"12.34"},
+		"refunds": {"1.00;0.05"},
+		"tip":     {"1.50"},
+		"other":   {"00000001", "00000002"},
+	}
+
+	It("encodes registered types as single values", func() {
+		v, err := urlvalues.MarshalURLValues(o)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(values))
+	})
+
+	It("decodes registered types", func() {
+		var out order
+		Expect(urlvalues.UnmarshalURLValues(values, &out)).To(Succeed())
+		Expect(out).To(Equal(o))
+	})
+
+	It("uses codecs for map values", func() {
+		v, err := urlvalues.MarshalURLValues(map[string]any{"id": shortID{1, 2, 3, 4}, "total": cents(5)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"id": {"01020304"}, "total": {"0.05"}}))
+
+		m, err := urlvalues.DecodeMap[shortID](url.Values{"id": {"01020304"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(Equal(map[string]shortID{"id": {1, 2, 3, 4}}))
+	})
+
+	It("uses codecs in the exported helpers", func() {
+		s, err := urlvalues.FormatValue(reflect.ValueOf(cents(99)), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s).To(Equal("0.99"))
+
+		v, err := urlvalues.ParseValues([]string{"0a0b0c0d"}, reflect.TypeFor[*shortID](), "", ",")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v.Interface()).To(Equal(&shortID{10, 11, 12, 13}))
+	})
+
+	It("returns codec errors", func() {
+		_, err := urlvalues.MarshalURLValues(order{Total: -1})
+		Expect(err).To(MatchError("negative amount"))
+
+		var out order
+		err = urlvalues.UnmarshalURLValues(url.Values{"id": {"nothex"}}, &out)
+		Expect(err).To(MatchError(ContainSubstring("invalid id")))
+	})
+
+	It("reports registered types", func() {
+		Expect(urlvalues.HasCodec(reflect.TypeFor[shortID]())).To(BeTrue())
+		Expect(urlvalues.HasCodec(reflect.TypeFor[[4]byte]())).To(BeFalse())
+	})
+
+	It("panics on nil functions", func() {
+		Expect(func() { urlvalues.RegisterCodec[cents](nil, nil) }).To(Panic())
+	})
+})
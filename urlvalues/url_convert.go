@@ -0,0 +1,157 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrSkip is returned by ParseTag when the tag is "-", and by FormatValue when the value (e.g. a nil pointer) has no
+// string representation and should be left out of the output entirely.
+var ErrSkip = errSkip
+
+// Tag is the parsed form of a struct tag written in the same syntax as the "url" tag, i.e.
+// `name|alias,omitempty,join='...',required,default='...'`. It is exported so that sibling encodings can share the tag
+// grammar.
+type Tag struct {
+	// Name is the parameter name. It may be empty, in which case callers should fall back to the field name.
+	Name string
+	// Aliases are the other names, separated from Name by '|', that a parameter is accepted under when decoding, e.g.
+	// the old names of a renamed parameter. Marshaling always uses Name.
+	Aliases []string
+	// OmitEmpty is true if the tag contained the omitempty option.
+	OmitEmpty bool
+	// OmitZero is true if the tag contained the omitzero option.
+	OmitZero bool
+	// OmitNil is true if the tag contained the omitnil option.
+	OmitNil bool
+	// Join is the string that slice and array elements are joined with, if the tag contained a join option.
+	Join string
+	// Required is true if the tag contained the required option. UnmarshalURLValues reports a missing required
+	// parameter that has no default as an error.
+	Required bool
+	// Default is the value of the default='...' option, and HasDefault reports whether the option was present.
+	// UnmarshalURLValues parses it in place of a missing parameter.
+	Default    string
+	HasDefault bool
+	// Inline is true if the tag contained the inline option, which flattens the parameters of a struct field into
+	// its parent, prefixed with Name.
+	Inline bool
+}
+
+// ParseTag parses a struct tag using the "url" tag grammar. If the tag is "-", ErrSkip is returned.
+func ParseTag(tag string) (Tag, error) {
+	t, err := parseTag(tag)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	return Tag{
+		Name:       t.name,
+		Aliases:    t.aliases,
+		OmitEmpty:  t.omitEmpty,
+		OmitZero:   t.omitZero,
+		OmitNil:    t.omitNil,
+		Join:       t.joinString,
+		Required:   t.required,
+		Default:    t.defaultValue,
+		HasDefault: t.hasDefault,
+		Inline:     t.inline,
+	}, nil
+}
+
+// FormatValue converts a single scalar value into a string using the same rules that MarshalURLValues applies to
+// struct fields. The format argument has the same meaning as the "urlformat" struct tag. Nil pointers result in
+// ErrSkip.
+func FormatValue(v reflect.Value, format string) (string, error) {
+	if !v.IsValid() {
+		return "", errors.New("invalid value")
+	}
+
+	return stringFromValue(v, v.Type(), format)
+}
+
+// FormatValues converts a field value into strings using the rules MarshalURLValues applies to struct fields: a list
+// (see IsList), or a pointer to one, produces a string per element, and any other value produces a single string. A
+// non-nil interface is converted by its dynamic value.
+// Values that convert to ErrSkip are left out. The result is nil if v produces nothing at all, such as a nil pointer
+// or a nil slice, and empty (but not nil) for an empty list. The format argument has the same meaning as the
+// "urlformat" struct tag. Callers join the strings or write them separately, as their encoding requires.
+func FormatValues(v reflect.Value, format string) ([]string, error) {
+	return FormatValuesFunc(v, func(v reflect.Value) (string, error) {
+		return FormatValue(v, format)
+	})
+}
+
+// FormatValuesFunc is FormatValues with a custom conversion for single values, for encodings that write some types
+// differently. format may return ErrSkip to leave a value out.
+func FormatValuesFunc(v reflect.Value, format func(reflect.Value) (string, error)) ([]string, error) {
+	if !v.IsValid() {
+		return nil, errors.New("invalid value")
+	}
+
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	list := v
+	if list.Kind() == reflect.Pointer {
+		if list.IsNil() {
+			return nil, nil
+		}
+
+		list = list.Elem()
+	}
+
+	if !isList(list.Type()) {
+		// single values keep their pointer, so that methods with pointer receivers, such as Error, are found
+		s, err := format(v)
+		if err != nil {
+			if errors.Is(err, ErrSkip) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		return []string{s}, nil
+	}
+
+	if list.Kind() == reflect.Slice && list.IsNil() {
+		return nil, nil
+	}
+
+	strs := make([]string, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		s, err := format(list.Index(i))
+		if err != nil {
+			if errors.Is(err, ErrSkip) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		strs = append(strs, s)
+	}
+
+	return strs, nil
+}
+
+// IsList reports whether values of t are written as one value per element: t is a slice or an array and has no
+// codec (see HasCodec). Pointers are not dereferenced.
+func IsList(t reflect.Type) bool {
+	return isList(t)
+}
+
+// ParseValue converts s into a value of type t using the same rules that UnmarshalURLValues applies to struct fields.
+// The format argument has the same meaning as the "urlformat" struct tag.
+func ParseValue(s string, t reflect.Type, format string) (reflect.Value, error) {
+	return fromStringToValue(s, t, format)
+}
+
+// ParseValues converts one or more strings into a value of type t, which may be a scalar, a slice, an array, or a
+// pointer to any of those. If join is not empty and only one string is given, it is split on join first, exactly as
+// UnmarshalURLValues does for fields with a join option.
+func ParseValues(values []string, t reflect.Type, format string, join string) (reflect.Value, error) {
+	return fromStringsToValue(values, t, format, join)
+}
@@ -0,0 +1,232 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// ErrLimitExceeded is wrapped by the errors a Decoder returns when its input exceeds one of its Limits.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// Limits bounds the input a Decoder will accept. A zero value for any field means that dimension is unlimited.
+type Limits struct {
+	// MaxBytes is the maximum size of the encoded body.
+	MaxBytes int64
+	// MaxKeys is the maximum number of distinct parameter names.
+	MaxKeys int
+	// MaxValuesPerKey is the maximum number of times a single parameter may repeat.
+	MaxValuesPerKey int
+	// MaxKeyLength is the maximum length of an encoded parameter name.
+	MaxKeyLength int
+	// MaxValueLength is the maximum length of an encoded parameter value.
+	MaxValueLength int
+}
+
+// DefaultLimits are the limits a new Decoder starts with.
+var DefaultLimits = Limits{
+	MaxBytes:        10 << 20,
+	MaxKeys:         1000,
+	MaxValuesPerKey: 1000,
+	MaxKeyLength:    256,
+	MaxValueLength:  1 << 20,
+}
+
+// Decoder reads application/x-www-form-urlencoded data from an io.Reader and unmarshals it. Unlike url.ParseQuery,
+// which needs the whole body in memory first, the Decoder enforces its Limits while it reads, so oversized or
+// maliciously crafted bodies are rejected before they are buffered, and long before reflection begins.
+type Decoder struct {
+	r        io.Reader
+	limits   Limits
+	escaping Escaping
+	opts     decodeOptions
+}
+
+// decodeOptions are the settings of a Decoder that change how values are unmarshaled, as opposed to how they are read.
+// The zero value gives the behavior of UnmarshalURLValues.
+type decodeOptions struct {
+	infer        TypeInference
+	normalizeKey KeyNormalizer
+	onAlias      func(name, alias string)
+	fieldOptions
+}
+
+func (o decodeOptions) inference() TypeInference {
+	if o.infer == nil {
+		return InferDefault
+	}
+
+	return o.infer
+}
+
+// NewDecoder returns a Decoder that reads from r using DefaultLimits.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		r:      r,
+		limits: DefaultLimits,
+	}
+}
+
+// SetLimits replaces the limits of the Decoder.
+func (d *Decoder) SetLimits(limits Limits) {
+	d.limits = limits
+}
+
+// SetEscaping selects how the Decoder unescapes keys and values. The default, FormEscaping, accepts both '+' and
+// "%20" as a space and so reads the output of every Escaping. Use RFC3986Escaping when a literal '+' must be kept.
+func (d *Decoder) SetEscaping(escaping Escaping) {
+	d.escaping = escaping
+}
+
+// SetInference selects how values are typed when decoding into a map[string]any. A nil infer restores InferDefault.
+func (d *Decoder) SetInference(infer TypeInference) {
+	d.opts.infer = infer
+}
+
+// SetKeyMatching selects how parameter names are matched to struct fields. A nil normalize, the default, matches names
+// exactly; CaseInsensitive or a custom KeyNormalizer relax that. Decoding fails if two different parameters match the
+// same field.
+func (d *Decoder) SetKeyMatching(normalize KeyNormalizer) {
+	d.opts.normalizeKey = normalize
+}
+
+// SetAliasHook registers a function that is called whenever a struct field is decoded from one of the aliases in its
+// tag rather than its name, e.g. to log clients that still send a deprecated parameter. A nil hook disables it.
+func (d *Decoder) SetAliasHook(hook func(name, alias string)) {
+	d.opts.onAlias = hook
+}
+
+// SetNaming selects how fields without a name in their tag are named, e.g. SnakeCase. A nil naming, the default, uses
+// the Go field name. Use the same strategy on the Encoder that produces the input.
+func (d *Decoder) SetNaming(naming NamingStrategy) {
+	d.opts.naming = naming
+}
+
+// SetTagNames lists the struct tags a field's parameter name and options are read from, in priority order, e.g.
+// SetTagNames("url", "json") to fall back to the json tag of fields that have no url tag. Only the winning tag is
+// used, so `json:"-"` skips a field and `json:",omitempty"` omits it just as the url tag would. Options of other
+// encodings that the url tag does not know, such as json's "string", are ignored. With no names, only "url" is read.
+// A field with a "path" tag is never bound through a fallback tag; only a url tag makes it a query parameter as well.
+func (d *Decoder) SetTagNames(names ...string) {
+	d.opts.tagNames = names
+}
+
+// Decode reads the form from the underlying reader and unmarshals it into a, following the same rules as
+// UnmarshalURLValues.
+func (d *Decoder) Decode(a any) error {
+	values, err := d.ReadValues()
+	if err != nil {
+		return err
+	}
+
+	return unmarshalValues(values, a, d.opts)
+}
+
+// DecodeValues unmarshals values that have already been parsed, such as r.URL.Query(), into a, applying the options of
+// the Decoder. It does not use the underlying reader or the Limits, so a Decoder created with NewDecoder(nil) can be
+// kept around just for its options.
+func (d *Decoder) DecodeValues(values url.Values, a any) error {
+	return unmarshalValues(values, a, d.opts)
+}
+
+// ReadValues reads the form from the underlying reader without unmarshaling it.
+func (d *Decoder) ReadValues() (url.Values, error) {
+	if d.r == nil {
+		return nil, errors.New("reader must not be nil")
+	}
+
+	r := d.r
+	if d.limits.MaxBytes > 0 {
+		r = io.LimitReader(r, d.limits.MaxBytes+1)
+	}
+
+	br := bufio.NewReader(r)
+	values := url.Values{}
+
+	var (
+		read  int64
+		key   strings.Builder
+		value strings.Builder
+		inKey = true
+	)
+
+	flush := func() error {
+		if key.Len() == 0 && value.Len() == 0 && inKey {
+			return nil
+		}
+
+		rawKey, rawValue := key.String(), value.String()
+		key.Reset()
+		value.Reset()
+		inKey = true
+
+		k, err := d.escaping.Unescape(rawKey)
+		if err != nil {
+			return err
+		}
+
+		v, err := d.escaping.Unescape(rawValue)
+		if err != nil {
+			return err
+		}
+
+		existing, ok := values[k]
+		if !ok && d.limits.MaxKeys > 0 && len(values) >= d.limits.MaxKeys {
+			return fmt.Errorf("%w: more than %d keys", ErrLimitExceeded, d.limits.MaxKeys)
+		}
+
+		if d.limits.MaxValuesPerKey > 0 && len(existing) >= d.limits.MaxValuesPerKey {
+			return fmt.Errorf("%w: more than %d values for key %q", ErrLimitExceeded, d.limits.MaxValuesPerKey, k)
+		}
+
+		values[k] = append(existing, v)
+		return nil
+	}
+
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if read++; d.limits.MaxBytes > 0 && read > d.limits.MaxBytes {
+			return nil, fmt.Errorf("%w: body is larger than %d bytes", ErrLimitExceeded, d.limits.MaxBytes)
+		}
+
+		switch {
+		case b == '&':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case b == ';':
+			return nil, errors.New("invalid semicolon separator in query")
+		case b == '=' && inKey:
+			inKey = false
+		case inKey:
+			if d.limits.MaxKeyLength > 0 && key.Len() >= d.limits.MaxKeyLength {
+				return nil, fmt.Errorf("%w: key is longer than %d bytes", ErrLimitExceeded, d.limits.MaxKeyLength)
+			}
+
+			key.WriteByte(b)
+		default:
+			if d.limits.MaxValueLength > 0 && value.Len() >= d.limits.MaxValueLength {
+				return nil, fmt.Errorf("%w: value is longer than %d bytes", ErrLimitExceeded, d.limits.MaxValueLength)
+			}
+
+			value.WriteByte(b)
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
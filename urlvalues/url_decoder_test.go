@@ -0,0 +1,67 @@
+package urlvalues_test
+
+import (
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var _ = Describe("Decoder", func() {
+	type webhook struct {
+		Event string   `url:"event"`
+		IDs   []int64  `url:"id"`
+		Tags  []string `url:"tags,join=','"`
+	}
+
+	It("decodes a form body into a struct", func() {
+		var w webhook
+		d := urlvalues.NewDecoder(strings.NewReader("event=push%21&id=1&id=2&tags=a%2Cb&empty="))
+		Expect(d.Decode(&w)).To(Succeed())
+		Expect(w).To(Equal(webhook{Event: "push!", IDs: []int64{1, 2}, Tags: []string{"a", "b"}}))
+	})
+
+	It("reads the same values as url.ParseQuery", func() {
+		body := "a=1&b=2+3&a=4&c&d=&=e&&f=%20"
+		expected, err := url.ParseQuery(body)
+		Expect(err).NotTo(HaveOccurred())
+
+		values, err := urlvalues.NewDecoder(strings.NewReader(body)).ReadValues()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(expected))
+	})
+
+	DescribeTable("enforces its limits",
+		func(body string, limits urlvalues.Limits) {
+			d := urlvalues.NewDecoder(strings.NewReader(body))
+			d.SetLimits(limits)
+
+			_, err := d.ReadValues()
+			Expect(err).To(MatchError(urlvalues.ErrLimitExceeded))
+		},
+		Entry("body size", "a=1&b=2", urlvalues.Limits{MaxBytes: 6}),
+		Entry("key count", "a=1&b=2&c=3", urlvalues.Limits{MaxKeys: 2}),
+		Entry("values per key", "a=1&a=2&a=3", urlvalues.Limits{MaxValuesPerKey: 2}),
+		Entry("key length", "abcdef=1", urlvalues.Limits{MaxKeyLength: 5}),
+		Entry("value length", "a=123456", urlvalues.Limits{MaxValueLength: 5}),
+	)
+
+	It("accepts input exactly at its limits", func() {
+		d := urlvalues.NewDecoder(strings.NewReader("ab=12&ab=34"))
+		d.SetLimits(urlvalues.Limits{MaxBytes: 11, MaxKeys: 1, MaxValuesPerKey: 2, MaxKeyLength: 2, MaxValueLength: 2})
+
+		values, err := d.ReadValues()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values["ab"]).To(Equal([]string{"12", "34"}))
+	})
+
+	It("fails on malformed input", func() {
+		_, err := urlvalues.NewDecoder(strings.NewReader("a=%zz")).ReadValues()
+		Expect(err).To(HaveOccurred())
+
+		_, err = urlvalues.NewDecoder(strings.NewReader("a=1;b=2")).ReadValues()
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,63 @@
+package urlvalues_test
+
+import (
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var _ = Describe("Required and default options", func() {
+	type search struct {
+		Query   string         `url:"q|query,required"`
+		Limit   int            `url:"limit,required,default='20'"`
+		Sort    []string       `url:"sort,default='name,-created'"`
+		Tags    []string       `url:"tags,join='|',default='a|b'"`
+		Timeout *time.Duration `url:"timeout,default='5s'"`
+		Empty   []int          `url:"empty,default=''"`
+		Page    int            `url:"page"`
+	}
+
+	It("fills missing parameters from their defaults", func() {
+		var out search
+		Expect(urlvalues.UnmarshalURLValues(url.Values{"q": {"go"}}, &out)).To(Succeed())
+
+		timeout := 5 * time.Second
+		Expect(out).To(Equal(search{
+			Query: "go", Limit: 20, Sort: []string{"name", "-created"}, Tags: []string{"a", "b"}, Timeout: &timeout,
+		}))
+	})
+
+	It("prefers the parameters that are present", func() {
+		var out search
+		Expect(urlvalues.UnmarshalURLValues(url.Values{
+			"query": {"go"}, "limit": {"5"}, "sort": {"age"}, "tags": {"x"}, "timeout": {"1m"},
+		}, &out)).To(Succeed())
+		Expect(out.Limit).To(Equal(5))
+		Expect(out.Sort).To(Equal([]string{"age"}))
+		Expect(out.Tags).To(Equal([]string{"x"}))
+		Expect(*out.Timeout).To(Equal(time.Minute))
+	})
+
+	It("reports a missing required parameter", func() {
+		var out search
+		err := urlvalues.UnmarshalURLValues(url.Values{"limit": {"5"}}, &out)
+		Expect(err).To(MatchError("required parameter q is missing"))
+	})
+
+	It("reports an invalid default", func() {
+		var out struct {
+			Limit int `url:"limit,default='many'"`
+		}
+		Expect(urlvalues.UnmarshalURLValues(url.Values{}, &out)).NotTo(Succeed())
+	})
+
+	It("does not apply the options when marshaling", func() {
+		v, err := urlvalues.MarshalURLValues(search{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v.Get("limit")).To(Equal("0"))
+		Expect(v).NotTo(HaveKey("sort"))
+	})
+})
@@ -0,0 +1,171 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Escaping selects how keys and values are percent-encoded.
+type Escaping int
+
+const (
+	// FormEscaping escapes exactly like url.QueryEscape (and url.Values.Encode): spaces become '+'.
+	FormEscaping Escaping = iota
+	// RFC3986Escaping escapes every byte except the RFC 3986 unreserved characters (ALPHA, DIGIT, '-', '.', '_' and
+	// '~'), so spaces become "%20".
+	RFC3986Escaping
+	// PreserveReservedEscaping is RFC3986Escaping, except that the reserved characters that are unambiguous inside a
+	// query value ('!', '$', '\'', '(', ')', '*', ',', '/', ':', '?' and '@') are left as they are. This keeps lists
+	// produced by a join option readable, e.g. "ids=1,2,3" instead of "ids=1%2C2%2C3". The separators '&' and '=',
+	// and '+' (which form decoders read as a space), are still escaped.
+	PreserveReservedEscaping
+)
+
+// Escape percent-encodes s according to e.
+func (e Escaping) Escape(s string) string {
+	switch e {
+	case RFC3986Escaping:
+		return escapeExcept(s, isUnreserved)
+	case PreserveReservedEscaping:
+		return escapeExcept(s, isUnreservedOrSafeReserved)
+	default:
+		return url.QueryEscape(s)
+	}
+}
+
+// Unescape reverses Escape. FormEscaping is lenient: it accepts '+' and "%20" alike as a space, so it can read the
+// output of every Escaping. The other styles treat '+' as a literal plus sign, as RFC 3986 does.
+func (e Escaping) Unescape(s string) (string, error) {
+	switch e {
+	case RFC3986Escaping, PreserveReservedEscaping:
+		return url.PathUnescape(s)
+	default:
+		return url.QueryUnescape(s)
+	}
+}
+
+// EncodeValues works like values.Encode(), sorting by key, but escapes keys and values according to escaping.
+func EncodeValues(values url.Values, escaping Escaping) string {
+	return OrderedFromValues(values).EncodeWith(escaping)
+}
+
+func isUnreserved(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isUnreservedOrSafeReserved(c byte) bool {
+	return isUnreserved(c) || strings.IndexByte("!$'()*,/:?@", c) >= 0
+}
+
+// escapeExcept percent-encodes every byte of s for which keep returns false.
+func escapeExcept(s string, keep func(byte) bool) string {
+	const upperhex = "0123456789ABCDEF"
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if keep(c) {
+			sb.WriteByte(c)
+			continue
+		}
+
+		sb.WriteByte('%')
+		sb.WriteByte(upperhex[c>>4])
+		sb.WriteByte(upperhex[c&15])
+	}
+
+	return sb.String()
+}
+
+// Encoder writes application/x-www-form-urlencoded data directly to an io.Writer. Struct fields are written in
+// declaration order as they are converted, without building an intermediate url.Values or sorting its keys. The
+// values of a map[string]any or a URLValuesMarshaler are written with their keys sorted, as url.Values.Encode does.
+type Encoder struct {
+	w        io.Writer
+	escaping Escaping
+	opts     encodeOptions
+}
+
+// NewEncoder returns an Encoder that writes to w using FormEscaping.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// SetEscaping selects how the Encoder percent-encodes keys and values.
+func (e *Encoder) SetEscaping(escaping Escaping) {
+	e.escaping = escaping
+}
+
+// SetNaming selects how fields without a name in their tag are named, e.g. SnakeCase. A nil naming, the default, uses
+// the Go field name.
+func (e *Encoder) SetNaming(naming NamingStrategy) {
+	e.opts.naming = naming
+}
+
+// SetTagNames lists the struct tags a field's parameter name and options are read from, in priority order. See
+// Decoder.SetTagNames.
+func (e *Encoder) SetTagNames(names ...string) {
+	e.opts.tagNames = names
+}
+
+// SetMapFormats gives the values of a map the formats a "urlformat" tag gives struct fields, keyed by map key. For
+// example, SetMapFormats(map[string]string{"since": time.DateOnly}) formats a time.Time stored under "since" as a date.
+// Keys that are not listed use the default format.
+func (e *Encoder) SetMapFormats(formats map[string]string) {
+	e.opts.formats = formats
+}
+
+// Marshal converts a into a url.Values like MarshalURLValues, applying the options of the Encoder. It does not use the
+// underlying writer, so an Encoder created with NewEncoder(nil) can be kept around just for its options.
+func (e *Encoder) Marshal(a any) (url.Values, error) {
+	if u, ok := a.(URLValuesMarshaler); ok {
+		return u.MarshalURLValues()
+	}
+
+	values := url.Values{}
+	if err := marshalTo(a, false, emitToValues(values), e.opts); err != nil {
+		return url.Values{}, err
+	}
+
+	return values, nil
+}
+
+// Encode writes the encoding of a to the underlying writer. It accepts the same arguments as MarshalURLValues. A
+// key that occurs more than once is written once per value, in the order the values were produced.
+func (e *Encoder) Encode(a any) error {
+	if e.w == nil {
+		return errors.New("writer must not be nil")
+	}
+
+	bw := bufio.NewWriter(e.w)
+	first := true
+	err := marshalTo(a, true, func(key, value string, _ bool) error {
+		if !first {
+			if err := bw.WriteByte('&'); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		if _, err := bw.WriteString(e.escaping.Escape(key)); err != nil {
+			return err
+		}
+
+		if err := bw.WriteByte('='); err != nil {
+			return err
+		}
+
+		_, err := bw.WriteString(e.escaping.Escape(value))
+		return err
+	}, e.opts)
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
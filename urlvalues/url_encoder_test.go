@@ -0,0 +1,118 @@
+package urlvalues_test
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+var _ = Describe("Encoder", func() {
+	type bulk struct {
+		Zeta   string        `url:"zeta"`
+		Alpha  []int         `url:"alpha"`
+		Joined []string      `url:"joined,join=', '"`
+		Space  string        `url:"a key"`
+		Wait   time.Duration `url:"wait" urlformat:"int,s"`
+		Nil    *int          `url:"nil"`
+	}
+
+	b := bulk{
+		Zeta:   "last~first",
+		Alpha:  []int{2, 1},
+		Joined: []string{"x", "y"},
+		Space:  "a b",
+		Wait:   time.Minute,
+	}
+
+	It("writes struct fields in declaration order", func() {
+		var buf bytes.Buffer
+		Expect(urlvalues.NewEncoder(&buf).Encode(b)).To(Succeed())
+		Expect(buf.String()).To(Equal("zeta=last~first&alpha=2&alpha=1&joined=x%2C+y&a+key=a+b&wait=60"))
+	})
+
+	It("supports RFC 3986 escaping", func() {
+		var buf bytes.Buffer
+		e := urlvalues.NewEncoder(&buf)
+		e.SetEscaping(urlvalues.RFC3986Escaping)
+		Expect(e.Encode(&b)).To(Succeed())
+		Expect(buf.String()).To(Equal("zeta=last~first&alpha=2&alpha=1&joined=x%2C%20y&a%20key=a%20b&wait=60"))
+	})
+
+	It("preserves reserved characters in joined lists", func() {
+		var buf bytes.Buffer
+		e := urlvalues.NewEncoder(&buf)
+		e.SetEscaping(urlvalues.PreserveReservedEscaping)
+		Expect(e.Encode(map[string]any{"ids": "1,2,3", "q": "a+b=c&d (e)", "t": "12:00/@x"})).To(Succeed())
+		Expect(buf.String()).To(Equal("ids=1,2,3&q=a%2Bb%3Dc%26d%20(e)&t=12:00/@x"))
+	})
+
+	DescribeTable("escapes values for each style",
+		func(escaping urlvalues.Escaping, expected string) {
+			values := url.Values{"k": {"a b+c,d~e/é"}}
+			Expect(urlvalues.EncodeValues(values, escaping)).To(Equal(expected))
+
+			decoded, err := urlvalues.NewDecoder(strings.NewReader(expected)).ReadValues()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded).To(Equal(values))
+		},
+		Entry("form", urlvalues.FormEscaping, "k=a+b%2Bc%2Cd~e%2F%C3%A9"),
+		Entry("RFC 3986", urlvalues.RFC3986Escaping, "k=a%20b%2Bc%2Cd~e%2F%C3%A9"),
+		Entry("preserve reserved", urlvalues.PreserveReservedEscaping, "k=a%20b%2Bc,d~e/%C3%A9"),
+	)
+
+	It("keeps literal plus signs when decoding RFC 3986", func() {
+		d := urlvalues.NewDecoder(strings.NewReader("k=a+b%20c"))
+		d.SetEscaping(urlvalues.RFC3986Escaping)
+
+		values, err := d.ReadValues()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values.Get("k")).To(Equal("a+b c"))
+	})
+
+	It("produces the same values as MarshalURLValues", func() {
+		var buf bytes.Buffer
+		Expect(urlvalues.NewEncoder(&buf).Encode(numberTestValue())).To(Succeed())
+
+		expected, err := urlvalues.MarshalURLValues(numberTestValue())
+		Expect(err).NotTo(HaveOccurred())
+
+		actual, err := url.ParseQuery(buf.String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(Equal(expected))
+	})
+
+	It("writes maps and marshalers in sorted key order", func() {
+		var buf bytes.Buffer
+		e := urlvalues.NewEncoder(&buf)
+		Expect(e.Encode(map[string]any{"b": 1, "a": []string{"x", "y"}, "c": "z"})).To(Succeed())
+		Expect(buf.String()).To(Equal("a=x&a=y&b=1&c=z"))
+
+		buf.Reset()
+		Expect(e.Encode(custom{v: url.Values{"b": {"1"}, "a": {"2"}}})).To(Succeed())
+		Expect(buf.String()).To(Equal("a=2&b=1"))
+	})
+
+	It("fails on invalid arguments and writers", func() {
+		Expect(urlvalues.NewEncoder(&bytes.Buffer{}).Encode(3)).To(HaveOccurred())
+		Expect(urlvalues.NewEncoder(failingWriter{}).Encode(b)).To(HaveOccurred())
+		Expect(urlvalues.NewEncoder(nil).Encode(b)).To(HaveOccurred())
+	})
+})
+
+func numberTestValue() fcFormat {
+	return fcFormat{
+		F32Maxe: xf32, F64Ming: nf64, C64MaxG: xc64, C128Minf: nc128,
+	}
+}
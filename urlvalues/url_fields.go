@@ -0,0 +1,286 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// InQuery marks parameters that are read from the query string or form body.
+	InQuery = "query"
+	// InPath marks parameters that are read from the request path.
+	InPath = "path"
+)
+
+// Field describes how a single struct field maps to a parameter. It is the metadata MarshalURLValues and
+// UnmarshalURLValues work from, exported so that documentation and schema generators agree with the wire format.
+type Field struct {
+	// Name is the parameter name.
+	Name string
+	// In is InQuery or InPath.
+	In string
+	// StructField is the Go field the parameter is bound to.
+	StructField reflect.StructField
+	// Tag is the parsed "url" or "path" tag. It is the zero Tag (other than its Name) for untagged fields. The Aliases
+	// of a flattened parameter carry the same prefix as its Name.
+	Tag Tag
+	// Format is the value of the "urlformat" tag.
+	Format string
+
+	// untagged is true if Name was taken from the Go field name rather than a tag, so a NamingStrategy applies to it.
+	untagged bool
+	// prefix is the part of Name contributed by the inline struct fields the field is nested in.
+	prefix string
+}
+
+// defaultTagNames are the tags a field's parameter name is read from unless an Encoder or Decoder says otherwise.
+var defaultTagNames = []string{"url"}
+
+var fieldCache sync.Map // map[fieldCacheKey][]Field
+
+type fieldCacheKey struct {
+	t        reflect.Type
+	tagNames string
+}
+
+// fieldOptions are the Encoder and Decoder settings that change the field plan.
+type fieldOptions struct {
+	tagNames []string
+	naming   NamingStrategy
+}
+
+// fields returns the plan for t under o.
+func (o fieldOptions) fields(t reflect.Type) ([]Field, error) {
+	tagNames := o.tagNames
+	if len(tagNames) == 0 {
+		tagNames = defaultTagNames
+	}
+
+	fields, err := cachedFields(t, tagNames)
+	if err != nil || o.naming == nil {
+		return fields, err
+	}
+
+	named := make([]Field, len(fields))
+	copy(named, fields)
+	for i := range named {
+		if named[i].untagged {
+			named[i].Name = named[i].prefix + o.naming(named[i].StructField.Name)
+			named[i].Tag.Name = named[i].Name
+		}
+	}
+
+	return named, nil
+}
+
+// Fields returns the parameters of the struct type t, in field order. A field with both a "path" and a "url" tag
+// appears twice, first with In set to InPath. Unexported fields and fields tagged "-" are left out.
+//
+// Embedded structs without a tag name, and struct fields tagged with the inline option, are flattened into their
+// parent: `url:"created_,inline"` turns the From field of a Range into created_from, and `url:",inline"` adds no
+// prefix. The StructField of a flattened parameter has the full index path in its Index, and a parameter hides
+// parameters of the same name that are nested more deeply, as in encoding/json.
+func Fields(t reflect.Type) ([]Field, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.New("type must be a struct")
+	}
+
+	return cachedFields(t, defaultTagNames)
+}
+
+func cachedFields(t reflect.Type, tagNames []string) ([]Field, error) {
+	key := fieldCacheKey{t: t, tagNames: strings.Join(tagNames, " ")}
+	if fields, ok := fieldCache.Load(key); ok {
+		return fields.([]Field), nil
+	}
+
+	fields, err := typeFields(t, tagNames)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := fieldCache.LoadOrStore(key, fields)
+	return actual.([]Field), nil
+}
+
+// lookupTag returns the first of the tagNames that sf has.
+func lookupTag(sf reflect.StructField, tagNames []string) (string, bool) {
+	for _, name := range tagNames {
+		if tagString, ok := sf.Tag.Lookup(name); ok {
+			return tagString, true
+		}
+	}
+
+	return "", false
+}
+
+func typeFields(t reflect.Type, tagNames []string) ([]Field, error) {
+	fields, err := appendFields(nil, t, tagNames, nil, "", map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range fields {
+		if fields[i].untagged {
+			fields[i].Name = fields[i].prefix + fields[i].StructField.Name
+			fields[i].Tag.Name = fields[i].Name
+		}
+	}
+
+	return dominantFields(fields), nil
+}
+
+// appendFields appends the parameters of the struct type t to fields. index is the index path of t within the
+// outermost struct and prefix is prepended to the names of its parameters. Struct fields that are embedded without a
+// name, or tagged with the inline option, are flattened recursively; visiting tracks the types being flattened so that
+// a struct that embeds itself is reported instead of recursing forever.
+func appendFields(fields []Field, t reflect.Type, tagNames []string, index []int, prefix string, visiting map[reflect.Type]bool) ([]Field, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("type %s is inlined into itself", t)
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !(sf.Anonymous && sf.Type.Kind() == reflect.Struct && IsStructType(sf.Type)) {
+			// like encoding/json, the exported fields of unexported embedded structs are still flattened, but an
+			// unexported embedded pointer could not be allocated when decoding
+			continue
+		}
+
+		sf.Index = append(slices.Clone(index), i)
+		format := sf.Tag.Get("urlformat")
+
+		pathString, hasPath := sf.Tag.Lookup("path")
+		if hasPath && sf.IsExported() {
+			tag, err := fieldTag(sf, pathString)
+			if err != nil && !errors.Is(err, errSkip) {
+				return nil, err
+			}
+
+			if err == nil {
+				fields = append(fields, Field{
+					Name: prefix + tag.Name, In: InPath, StructField: sf, Tag: prefixAliases(tag, prefix), Format: format,
+					untagged: tag.Name == "", prefix: prefix,
+				})
+			}
+		}
+
+		tagString, hasURL := lookupTag(sf, tagNames)
+		if hasPath {
+			// a path parameter is also a query or form parameter only if its url tag says so: a fallback tag such as
+			// json names the field in another encoding, not a second parameter
+			tagString, hasURL = "", false
+			if slices.Contains(tagNames, "url") {
+				tagString, hasURL = sf.Tag.Lookup("url")
+			}
+
+			if !hasURL {
+				continue
+			}
+		}
+
+		var tag Tag
+		if hasURL {
+			var err error
+			if tag, err = fieldTag(sf, tagString); err != nil {
+				if errors.Is(err, errSkip) {
+					continue
+				}
+
+				return nil, err
+			}
+		}
+
+		if tag.Inline || (sf.Anonymous && tag.Name == "" && IsStructType(sf.Type)) {
+			structType := sf.Type
+			if structType.Kind() == reflect.Pointer {
+				structType = structType.Elem()
+			}
+
+			if structType.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("field %s: inline requires a struct, not %s", sf.Name, sf.Type)
+			}
+
+			var err error
+			if fields, err = appendFields(fields, structType, tagNames, sf.Index, prefix+tag.Name, visiting); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		fields = append(fields, Field{
+			Name: prefix + tag.Name, In: InQuery, StructField: sf, Tag: prefixAliases(tag, prefix), Format: format,
+			untagged: tag.Name == "", prefix: prefix,
+		})
+	}
+
+	return fields, nil
+}
+
+// prefixAliases returns tag with prefix prepended to its aliases, which, like the Name of a Field, are full parameter
+// names.
+func prefixAliases(tag Tag, prefix string) Tag {
+	if prefix == "" || len(tag.Aliases) == 0 {
+		return tag
+	}
+
+	tag.Aliases = slices.Clone(tag.Aliases)
+	for i := range tag.Aliases {
+		tag.Aliases[i] = prefix + tag.Aliases[i]
+	}
+
+	return tag
+}
+
+// IsStructType reports whether t is a struct, or a pointer to one, that holds parameters rather than being a single
+// value itself. time.Time and types with a codec (see HasCodec) are single values. It is exported so that sibling
+// encodings recurse into the same fields.
+func IsStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{}) && !HasCodec(t)
+}
+
+// dominantFields drops the parameters that are hidden by a parameter of the same name nested less deeply, the way
+// encoding/json resolves the fields of embedded structs.
+func dominantFields(fields []Field) []Field {
+	depth := make(map[string]int, len(fields))
+	for _, f := range fields {
+		key := f.In + " " + f.Name
+		if d, ok := depth[key]; !ok || len(f.StructField.Index) < d {
+			depth[key] = len(f.StructField.Index)
+		}
+	}
+
+	return slices.DeleteFunc(fields, func(f Field) bool {
+		return len(f.StructField.Index) > depth[f.In+" "+f.Name]
+	})
+}
+
+func fieldTag(sf reflect.StructField, tagString string) (Tag, error) {
+	tag, err := ParseTag(tagString)
+	if err != nil {
+		if errors.Is(err, errSkip) {
+			return tag, err
+		}
+
+		return tag, fmt.Errorf("field %s: %w", sf.Name, err)
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,43 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"net/url"
+	"reflect"
+)
+
+// Decode deserializes values into a new T and returns it. T may be a struct, a map with string keys, a type whose
+// pointer implements URLValuesUnmarshaler, or a pointer to any of those, in which case a new value is allocated. The
+// rules are those of UnmarshalURLValues.
+//
+//	q, err := urlvalues.Decode[SearchQuery](r.URL.Query())
+func Decode[T any](values url.Values) (T, error) {
+	var out T
+
+	if t := reflect.TypeFor[T](); t.Kind() == reflect.Pointer {
+		p := reflect.New(t.Elem())
+		if err := UnmarshalURLValues(values, p.Interface()); err != nil {
+			return out, err
+		}
+
+		return p.Interface().(T), nil
+	}
+
+	err := UnmarshalURLValues(values, &out)
+	return out, err
+}
+
+// Encode serializes a into a url.Values. It accepts the same types as MarshalURLValues.
+func Encode[T any](a T) (url.Values, error) {
+	return MarshalURLValues(a)
+}
+
+// DecodeMap deserializes values into a map whose values are parsed into V, e.g. DecodeMap[int] or
+// DecodeMap[[]time.Time]. Unlike Decode, the map shape is checked at compile time.
+func DecodeMap[V any](values url.Values) (map[string]V, error) {
+	return Decode[map[string]V](values)
+}
+
+// EncodeMap serializes m into a url.Values. Slice and array values produce one value per element.
+func EncodeMap[V any](m map[string]V) (url.Values, error) {
+	return MarshalURLValues(m)
+}
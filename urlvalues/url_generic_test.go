@@ -0,0 +1,119 @@
+package urlvalues_test
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var _ = Describe("Generic API", func() {
+	type search struct {
+		Query string   `url:"q"`
+		Page  int      `url:"page"`
+		Tags  []string `url:"tag"`
+	}
+
+	values := url.Values{"q": {"gophers"}, "page": {"2"}, "tag": {"go", "fun"}}
+
+	Describe("Decode", func() {
+		It("returns a struct", func() {
+			s, err := urlvalues.Decode[search](values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal(search{Query: "gophers", Page: 2, Tags: []string{"go", "fun"}}))
+		})
+
+		It("allocates pointer targets", func() {
+			s, err := urlvalues.Decode[*search](values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal(&search{Query: "gophers", Page: 2, Tags: []string{"go", "fun"}}))
+		})
+
+		It("uses URLValuesUnmarshaler", func() {
+			c, err := urlvalues.Decode[custom](url.Values{"a": {"b"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).NotTo(BeZero())
+		})
+
+		It("decodes into map[string]any", func() {
+			m, err := urlvalues.Decode[map[string]any](url.Values{"b": {"true"}, "n": {"1.5"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m).To(Equal(map[string]any{"b": true, "n": 1.5}))
+		})
+
+		It("rejects unsupported targets", func() {
+			_, err := urlvalues.Decode[int](values)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns parse errors", func() {
+			_, err := urlvalues.Decode[search](url.Values{"page": {"two"}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("DecodeMap", func() {
+		It("parses scalar values from the first value", func() {
+			m, err := urlvalues.DecodeMap[int](url.Values{"a": {"1", "2"}, "b": {"3"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m).To(Equal(map[string]int{"a": 1, "b": 3}))
+		})
+
+		It("parses slice values", func() {
+			m, err := urlvalues.DecodeMap[[]float64](url.Values{"a": {"1.5", "2"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m).To(Equal(map[string][]float64{"a": {1.5, 2}}))
+		})
+
+		It("keeps strings as sent", func() {
+			m, err := urlvalues.DecodeMap[[]string](values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(url.Values(m)).To(Equal(values))
+		})
+
+		It("names the failing parameter", func() {
+			_, err := urlvalues.DecodeMap[int](url.Values{"a": {"x"}})
+			Expect(err).To(MatchError(ContainSubstring("parameter a")))
+		})
+	})
+
+	Describe("Encode", func() {
+		It("encodes structs", func() {
+			v, err := urlvalues.Encode(search{Query: "gophers", Page: 2, Tags: []string{"go", "fun"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(values))
+		})
+
+		It("encodes typed maps", func() {
+			v, err := urlvalues.EncodeMap(map[string][]int{"id": {3, 1}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(url.Values{"id": {"3", "1"}}))
+
+			v, err = urlvalues.EncodeMap(map[string]bool{"debug": true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(url.Values{"debug": {"true"}}))
+		})
+
+		It("round trips typed maps", func() {
+			in := map[string]int{"a": 1, "b": 2}
+			v, err := urlvalues.EncodeMap(in)
+			Expect(err).NotTo(HaveOccurred())
+
+			out, err := urlvalues.DecodeMap[int](v)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal(in))
+		})
+
+		It("orders typed maps by key", func() {
+			o, err := urlvalues.MarshalOrderedURLValues(map[string]string{"b": "2", "a": "1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(o.Encode()).To(Equal("a=1&b=2"))
+		})
+
+		It("rejects unsupported values", func() {
+			_, err := urlvalues.Encode(42)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package urlvalues_test
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// holder returns a pointer to a struct with a single field "v" set to v and tagged with format. The field has the
+// type static, or the dynamic type of v if static is nil.
+func holder(v any, static reflect.Type, format string) any {
+	if static == nil {
+		static = reflect.TypeOf(v)
+	}
+
+	t := reflect.StructOf([]reflect.StructField{{
+		Name: "V",
+		Type: static,
+		Tag:  reflect.StructTag(`url:"v" urlformat:"` + format + `"`),
+	}})
+
+	s := reflect.New(t)
+	if v != nil {
+		s.Elem().Field(0).Set(reflect.ValueOf(v))
+	}
+
+	return s.Interface()
+}
+
+var _ = Describe("Struct and map conversion", func() {
+	when := time.Date(2024, time.March, 9, 14, 30, 0, 0, time.UTC)
+	seven := 7
+	var nilInt *int
+	anyType := reflect.TypeFor[any]()
+
+	DescribeTable("converts a value the same way in a struct field and a map value",
+		func(v any, static reflect.Type, format string, expected ...string) {
+			e := urlvalues.NewEncoder(nil)
+			e.SetMapFormats(map[string]string{"v": format})
+
+			fromStruct, err := e.Marshal(holder(v, static, format))
+			Expect(err).NotTo(HaveOccurred())
+
+			fromMap, err := e.Marshal(map[string]any{"v": v})
+			Expect(err).NotTo(HaveOccurred())
+
+			want := url.Values{}
+			for _, s := range expected {
+				want.Add("v", s)
+			}
+
+			Expect(fromStruct).To(Equal(want))
+			Expect(fromMap).To(Equal(want))
+		},
+		Entry("bool", true, nil, "", "true"),
+		Entry("bool with a format", true, nil, "int", "1"),
+		Entry("int", -42, nil, "", "-42"),
+		Entry("int8", int8(math.MinInt8), nil, "", "-128"),
+		Entry("int64", int64(math.MinInt64), nil, "", "-9223372036854775808"),
+		Entry("uint16", uint16(math.MaxUint16), nil, "", "65535"),
+		Entry("uint64", uint64(math.MaxUint64), nil, "", "18446744073709551615"),
+		Entry("float32", float32(0.1), nil, "", "0.1"),
+		Entry("float64", 0.1, nil, "", "0.1"),
+		Entry("large float64", 1e21, nil, "", "1000000000000000000000"),
+		Entry("small float64", 1.5e-7, nil, "", "0.00000015"),
+		Entry("float64 with a format", 1.5e-7, nil, "e", "1.5e-07"),
+		Entry("complex64", complex64(1+2i), nil, "", "(1+2i)"),
+		Entry("complex128", 1.5-0.25i, nil, "", "(1.5-0.25i)"),
+		Entry("complex128 with a format", 1.5-0.25i, nil, "e", "(1.5e+00-2.5e-01i)"),
+		Entry("string", "a b&c", nil, "", "a b&c"),
+		Entry("time.Time", when, nil, "", "2024-03-09T14:30:00Z"),
+		Entry("time.Time with a format", when, nil, time.DateOnly, "2024-03-09"),
+		Entry("time.Duration", 90*time.Second, nil, "", "1m30s"),
+		Entry("time.Duration with a format", 90*time.Second, nil, "int,s", "90"),
+		Entry("error", errors.New("boom"), reflect.TypeOf((*error)(nil)).Elem(), "", "boom"),
+		Entry("pointer", &seven, nil, "", "7"),
+		Entry("nil pointer", nilInt, nil, ""),
+		Entry("slice", []int{3, 1, 2}, nil, "", "3", "1", "2"),
+		Entry("slice with a format", []bool{true, false}, nil, "short", "T", "F"),
+		Entry("array", [2]string{"x", "y"}, nil, "", "x", "y"),
+		Entry("slice of times", []time.Time{when}, nil, time.Kitchen, "2:30PM"),
+		Entry("pointer to a slice", &[]float64{0.5, 2}, nil, "", "0.5", "2"),
+		Entry("codec", cents(1999), nil, "", "19.99"),
+		Entry("slice in an interface", []int{3, 1, 2}, anyType, "", "3", "1", "2"),
+		Entry("slice with a format in an interface", []bool{true, false}, anyType, "short", "T", "F"),
+		Entry("pointer in an interface", &seven, anyType, "", "7"),
+		Entry("pointer to a slice in an interface", &[]float64{0.5, 2}, anyType, "", "0.5", "2"),
+		Entry("time.Time in an interface", when, anyType, time.DateOnly, "2024-03-09"),
+		Entry("slice of times in an interface", []time.Time{when}, anyType, time.Kitchen, "2:30PM"),
+		Entry("nil in an interface", nil, anyType, ""),
+	)
+
+	It("formats only the map keys it is given a format for", func() {
+		e := urlvalues.NewEncoder(nil)
+		e.SetMapFormats(map[string]string{"since": time.DateOnly})
+
+		vals, err := e.Marshal(map[string]time.Time{"since": when, "until": when})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vals.Get("since")).To(Equal("2024-03-09"))
+		Expect(vals.Get("until")).To(Equal("2024-03-09T14:30:00Z"))
+	})
+
+	It("does not format map values without an Encoder", func() {
+		vals, err := urlvalues.MarshalURLValues(map[string]any{"ratio": 1e21})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vals.Get("ratio")).To(Equal("1000000000000000000000"))
+	})
+})
@@ -0,0 +1,117 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// TypeInference decides the Go value a parameter value becomes when it is decoded into a map[string]any, where there
+// is no field type to follow. InferDefault, InferStrings, InferJSON and InferNumbers are provided, and any other
+// function with this signature can be passed to Decoder.SetInference.
+type TypeInference func(s string) any
+
+// InferDefault is the inference UnmarshalURLValues uses. s becomes a bool if strconv.ParseBool accepts it (so "1" and
+// "0" are booleans), then a float64, a complex128 or an RFC3339 time.Time, and otherwise stays a string.
+func InferDefault(s string) any {
+	return fromStringToAny(s)
+}
+
+// InferStrings leaves every value as the string that was sent.
+func InferStrings(s string) any {
+	return s
+}
+
+// InferJSON infers the types a JSON decoder using json.Decoder.UseNumber would produce for the same text: "true" and
+// "false" become booleans, anything that is a valid JSON number becomes a json.Number, and everything else stays a
+// string.
+func InferJSON(s string) any {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if isJSONNumber(s) {
+		return json.Number(s)
+	}
+
+	return s
+}
+
+// InferNumbers prefers integers, so that 64-bit IDs keep their precision: s becomes an int64 if it fits, then a
+// uint64, then a float64 if it is a finite number. "true" and "false" become booleans, and everything else stays a
+// string.
+func InferNumbers(s string) any {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return u
+	}
+
+	if isJSONNumber(s) {
+		if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsInf(f, 0) {
+			return f
+		}
+	}
+
+	return s
+}
+
+// isJSONNumber reports whether s is a number in JSON syntax, which, unlike strconv.ParseFloat, rejects forms such as
+// "Inf", "0x1p3" and "1_000". It follows the grammar directly rather than using json.Valid, which would also accept
+// surrounding whitespace.
+func isJSONNumber(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+
+	switch {
+	case i < len(s) && s[i] == '0':
+		i++
+	case i < len(s) && s[i] >= '1' && s[i] <= '9':
+		i = skipDigits(s, i)
+	default:
+		return false
+	}
+
+	if i < len(s) && s[i] == '.' {
+		if i = skipDigits(s, i+1); s[i-1] == '.' {
+			return false
+		}
+	}
+
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+
+		start := i
+		if i = skipDigits(s, i); i == start {
+			return false
+		}
+	}
+
+	return i == len(s)
+}
+
+// skipDigits returns the index of the first byte at or after i in s that is not a decimal digit.
+func skipDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	return i
+}
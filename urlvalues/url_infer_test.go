@@ -0,0 +1,69 @@
+package urlvalues_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var _ = Describe("Type inference", func() {
+	DescribeTable("infers values",
+		func(infer urlvalues.TypeInference, s string, expected any) {
+			Expect(infer(s)).To(Equal(expected))
+		},
+		Entry("default: 1 is a bool", urlvalues.InferDefault, "1", true),
+		Entry("default: integers are floats", urlvalues.InferDefault, "42", float64(42)),
+		Entry("strings: numbers stay strings", urlvalues.InferStrings, "42", "42"),
+		Entry("strings: bools stay strings", urlvalues.InferStrings, "true", "true"),
+		Entry("json: true", urlvalues.InferJSON, "true", true),
+		Entry("json: 1 is a number", urlvalues.InferJSON, "1", json.Number("1")),
+		Entry("json: large IDs keep their digits", urlvalues.InferJSON, "9007199254740993", json.Number("9007199254740993")),
+		Entry("json: exponents", urlvalues.InferJSON, "-1.5e3", json.Number("-1.5e3")),
+		Entry("json: T is a string", urlvalues.InferJSON, "T", "T"),
+		Entry("json: Inf is a string", urlvalues.InferJSON, "Inf", "Inf"),
+		Entry("json: leading zeros are strings", urlvalues.InferJSON, "007", "007"),
+		Entry("json: complex-looking strings stay strings", urlvalues.InferJSON, "1+2i", "1+2i"),
+		Entry("json: trailing whitespace is a string", urlvalues.InferJSON, "1 ", "1 "),
+		Entry("json: leading whitespace is a string", urlvalues.InferJSON, "\t1", "\t1"),
+		Entry("json: a bare point is a string", urlvalues.InferJSON, "1.", "1."),
+		Entry("json: an empty exponent is a string", urlvalues.InferJSON, "1e+", "1e+"),
+		Entry("json: a lone minus is a string", urlvalues.InferJSON, "-", "-"),
+		Entry("numbers: padded floats are strings", urlvalues.InferNumbers, "2.5\n", "2.5\n"),
+		Entry("numbers: int64", urlvalues.InferNumbers, "9007199254740993", int64(9007199254740993)),
+		Entry("numbers: negative", urlvalues.InferNumbers, "-7", int64(-7)),
+		Entry("numbers: uint64", urlvalues.InferNumbers, "18446744073709551615", uint64(18446744073709551615)),
+		Entry("numbers: float64", urlvalues.InferNumbers, "2.5", 2.5),
+		Entry("numbers: 1 is not a bool", urlvalues.InferNumbers, "1", int64(1)),
+		Entry("numbers: false", urlvalues.InferNumbers, "false", false),
+		Entry("numbers: NaN is a string", urlvalues.InferNumbers, "NaN", "NaN"),
+		Entry("numbers: hex floats are strings", urlvalues.InferNumbers, "0x1p3", "0x1p3"),
+	)
+
+	It("is used by the Decoder for maps", func() {
+		var m map[string]any
+		d := urlvalues.NewDecoder(strings.NewReader("id=9007199254740993&id=2&debug=1&name=x"))
+		d.SetInference(urlvalues.InferNumbers)
+		Expect(d.Decode(&m)).To(Succeed())
+		Expect(m).To(Equal(map[string]any{
+			"id":    []any{int64(9007199254740993), int64(2)},
+			"debug": int64(1),
+			"name":  "x",
+		}))
+	})
+
+	It("accepts custom functions and already parsed values", func() {
+		var m map[string]any
+		d := urlvalues.NewDecoder(nil)
+		d.SetInference(func(s string) any { return len(s) })
+		Expect(d.DecodeValues(url.Values{"a": {"abc"}}, &m)).To(Succeed())
+		Expect(m).To(Equal(map[string]any{"a": 3}))
+
+		d.SetInference(nil)
+		Expect(d.DecodeValues(url.Values{"a": {"1"}}, &m)).To(Succeed())
+		Expect(m).To(Equal(map[string]any{"a": true}))
+	})
+})
@@ -0,0 +1,175 @@
+package urlvalues_test
+
+import (
+	"net/url"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+type dateRange struct {
+	From string `url:"from,omitempty"`
+	To   string `url:"to,omitempty"`
+}
+
+type Paging struct {
+	Limit  int `url:"limit,omitempty"`
+	Offset int `url:"offset,omitempty"`
+}
+
+type sorting struct {
+	Sort string `url:"sort,omitempty"`
+}
+
+type search struct {
+	Paging
+	sorting
+	Created  dateRange  `url:"created_,inline"`
+	Updated  *dateRange `url:"updated_,inline"`
+	Window   dateRange  `url:",inline"`
+	Query    string     `url:"q"`
+	Excluded Paging     `url:"-"`
+}
+
+var _ = Describe("Inline structs", func() {
+	s := search{
+		Paging:  Paging{Limit: 10},
+		sorting: sorting{Sort: "name"},
+		Created: dateRange{From: "2024-01-01", To: "2024-02-01"},
+		Updated: &dateRange{From: "2024-03-01"},
+		Window:  dateRange{To: "2024-04-01"},
+		Query:   "go",
+	}
+
+	values := url.Values{
+		"limit":        {"10"},
+		"sort":         {"name"},
+		"created_from": {"2024-01-01"},
+		"created_to":   {"2024-02-01"},
+		"updated_from": {"2024-03-01"},
+		"to":           {"2024-04-01"},
+		"q":            {"go"},
+	}
+
+	type flat struct {
+		Range dateRange `url:"r_,inline"`
+		Query string    `url:"q"`
+	}
+
+	It("lists flattened fields", func() {
+		fields, err := urlvalues.Fields(reflect.TypeOf(flat{}))
+		Expect(err).NotTo(HaveOccurred())
+
+		var names []string
+		for _, f := range fields {
+			names = append(names, f.Name)
+		}
+		Expect(names).To(Equal([]string{"r_from", "r_to", "q"}))
+		Expect(fields[1].StructField.Index).To(Equal([]int{0, 1}))
+	})
+
+	It("marshals prefixed and embedded fields", func() {
+		v, err := urlvalues.MarshalURLValues(s)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(values))
+	})
+
+	It("unmarshals prefixed and embedded fields", func() {
+		var out search
+		Expect(urlvalues.UnmarshalURLValues(values, &out)).To(Succeed())
+		Expect(out).To(Equal(s))
+	})
+
+	It("does not flatten named struct fields without inline", func() {
+		type nested struct {
+			Paging Paging `url:"paging"`
+		}
+
+		_, err := urlvalues.MarshalURLValues(nested{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("leaves nil inline pointers nil when none of their keys are present", func() {
+		type optional struct {
+			Range *dateRange `url:"r_,inline"`
+		}
+
+		var out optional
+		Expect(urlvalues.UnmarshalURLValues(url.Values{"x": {"1"}}, &out)).To(Succeed())
+		Expect(out.Range).To(BeNil())
+
+		v, err := urlvalues.MarshalURLValues(out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(BeEmpty())
+	})
+
+	It("lets shallower fields hide embedded ones", func() {
+		type outer struct {
+			Paging
+			Limit string `url:"limit"`
+		}
+
+		v, err := urlvalues.MarshalURLValues(outer{Paging: Paging{Limit: 5}, Limit: "all"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"limit": {"all"}}))
+	})
+
+	It("applies naming strategies after the prefix", func() {
+		type named struct {
+			Range struct{ StartAt string } `url:"range_,inline"`
+		}
+
+		e := urlvalues.NewEncoder(nil)
+		e.SetNaming(urlvalues.SnakeCase)
+
+		var n named
+		n.Range.StartAt = "now"
+		v, err := e.Marshal(n)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"range_start_at": {"now"}}))
+	})
+
+	It("prefixes the aliases of inline fields", func() {
+		type span struct {
+			From int `url:"from|start"`
+		}
+
+		type events struct {
+			Created span `url:"created_,inline"`
+			Updated span `url:"updated_,inline"`
+		}
+
+		var out events
+		Expect(urlvalues.UnmarshalURLValues(url.Values{"updated_start": {"5"}, "start": {"9"}}, &out)).To(Succeed())
+		Expect(out).To(Equal(events{Updated: span{From: 5}}))
+
+		out = events{}
+		d := urlvalues.NewDecoder(nil)
+		d.SetKeyMatching(urlvalues.CaseInsensitive)
+		Expect(d.DecodeValues(url.Values{"CREATED_START": {"3"}}, &out)).To(Succeed())
+		Expect(out).To(Equal(events{Created: span{From: 3}}))
+	})
+
+	It("rejects invalid inline fields", func() {
+		type notStruct struct {
+			Value int `url:"v_,inline"`
+		}
+
+		_, err := urlvalues.Fields(reflect.TypeOf(notStruct{}))
+		Expect(err).To(MatchError(ContainSubstring("inline requires a struct")))
+	})
+})
+
+type Recursive struct {
+	*Recursive `url:",inline"`
+	Value      string `url:"value"`
+}
+
+var _ = Describe("Recursive inline structs", func() {
+	It("are rejected", func() {
+		_, err := urlvalues.Fields(reflect.TypeOf(Recursive{}))
+		Expect(err).To(MatchError(ContainSubstring("inlined into itself")))
+	})
+})
@@ -0,0 +1,58 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var interfaceDecoders sync.Map // map[reflect.Type]func(string) (any, error)
+
+// RegisterInterfaceDecoder registers decode as the way to build values for fields, slice elements and map values of
+// the interface type t. The value decode returns must implement t, and a nil value leaves the field nil. It is meant
+// to be called from init functions, and panics if t is not an interface type or decode is nil:
+//
+//	urlvalues.RegisterInterfaceDecoder(reflect.TypeFor[Shape](), func(s string) (any, error) {
+//		return ParseShape(s)
+//	})
+//
+// Registering a decoder for any replaces the default, which parses values like UnmarshalURLValues does for a
+// map[string]any. The error interface is decoded with errors.New unless a decoder is registered for it. Interface
+// values are always marshaled according to their dynamic type, so no encoder is needed.
+func RegisterInterfaceDecoder(t reflect.Type, decode func(string) (any, error)) {
+	if t == nil || t.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("urlvalues: RegisterInterfaceDecoder of non-interface type %v", t))
+	}
+
+	if decode == nil {
+		panic("urlvalues: RegisterInterfaceDecoder with nil decode function")
+	}
+
+	interfaceDecoders.Store(t, decode)
+}
+
+// fromStringToInterface decodes s with the decoder registered for the interface type t. The boolean result is false
+// if no decoder is registered.
+func fromStringToInterface(s string, t reflect.Type) (reflect.Value, bool, error) {
+	decode, ok := interfaceDecoders.Load(t)
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+
+	v, err := decode.(func(string) (any, error))(s)
+	if err != nil {
+		return reflect.Zero(t), true, err
+	}
+
+	if v == nil {
+		return reflect.Zero(t), true, nil
+	}
+
+	if !reflect.TypeOf(v).Implements(t) {
+		return reflect.Zero(t), true, fmt.Errorf("decoded %T does not implement %s", v, t)
+	}
+
+	rv := reflect.New(t).Elem()
+	rv.Set(reflect.ValueOf(v))
+	return rv, true, nil
+}
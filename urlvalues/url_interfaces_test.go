@@ -0,0 +1,138 @@
+package urlvalues_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+type distance interface {
+	Meters() float64
+}
+
+type meters float64
+
+func (m meters) Meters() float64 { return float64(m) }
+
+type badDistance interface {
+	Bad()
+}
+
+func init() {
+	urlvalues.RegisterInterfaceDecoder(reflect.TypeFor[distance](), func(s string) (any, error) {
+		if s == "none" {
+			return nil, nil
+		}
+
+		f, err := strconv.ParseFloat(s, 64)
+		return meters(f), err
+	})
+
+	urlvalues.RegisterInterfaceDecoder(reflect.TypeFor[badDistance](), func(s string) (any, error) {
+		return s, nil
+	})
+}
+
+var _ = Describe("Interface fields", func() {
+	type route struct {
+		Length distance   `url:"length"`
+		Legs   []distance `url:"leg"`
+		Extra  any        `url:"extra"`
+		Notes  []any      `url:"note"`
+		Err    error      `url:"err"`
+	}
+
+	It("decodes registered interfaces", func() {
+		var r route
+		err := urlvalues.UnmarshalURLValues(url.Values{
+			"length": {"12.5"},
+			"leg":    {"5", "7.5"},
+			"extra":  {"42"},
+			"note":   {"true", "hello"},
+			"err":    {"boom"},
+		}, &r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Length).To(Equal(meters(12.5)))
+		Expect(r.Legs).To(Equal([]distance{meters(5), meters(7.5)}))
+		Expect(r.Extra).To(Equal(float64(42)))
+		Expect(r.Notes).To(Equal([]any{true, "hello"}))
+		Expect(r.Err).To(MatchError("boom"))
+	})
+
+	It("leaves fields nil when the decoder returns nil", func() {
+		r := route{Length: meters(1)}
+		Expect(urlvalues.UnmarshalURLValues(url.Values{"length": {"none"}}, &r)).To(Succeed())
+		Expect(r.Length).To(BeNil())
+	})
+
+	It("returns decoder errors", func() {
+		var r route
+		err := urlvalues.UnmarshalURLValues(url.Values{"length": {"far"}}, &r)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects decoded values that do not implement the interface", func() {
+		var v struct {
+			Bad badDistance `url:"bad"`
+		}
+
+		err := urlvalues.UnmarshalURLValues(url.Values{"bad": {"x"}}, &v)
+		Expect(err).To(MatchError(ContainSubstring("does not implement")))
+	})
+
+	It("rejects unregistered interfaces", func() {
+		var v struct {
+			Stringer interface{ String() string } `url:"s"`
+		}
+
+		err := urlvalues.UnmarshalURLValues(url.Values{"s": {"x"}}, &v)
+		Expect(err).To(MatchError(ContainSubstring("unsupported type")))
+	})
+
+	It("marshals interfaces by their dynamic type", func() {
+		v, err := urlvalues.MarshalURLValues(route{
+			Length: meters(12.5),
+			Legs:   []distance{meters(5), nil, meters(7.5)},
+			Extra:  3,
+			Notes:  []any{true, "hello", new(int)},
+			Err:    errors.New("boom"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{
+			"length": {"12.5"},
+			"leg":    {"5", "7.5"},
+			"extra":  {"3"},
+			"note":   {"true", "hello", "0"},
+			"err":    {"boom"},
+		}))
+	})
+
+	It("marshals lists held in interfaces by their dynamic type", func() {
+		v, err := urlvalues.MarshalURLValues(struct {
+			A any `url:"a"`
+			B any `url:"b,join=','"`
+			C any `url:"c"`
+		}{A: []int{1, 2}, B: []int{3, 4}, C: &[]string{"x"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"a": {"1", "2"}, "b": {"3,4"}, "c": {"x"}}))
+	})
+
+	It("skips nil interfaces", func() {
+		v, err := urlvalues.MarshalURLValues(route{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(BeEmpty())
+	})
+
+	It("panics on invalid registrations", func() {
+		Expect(func() {
+			urlvalues.RegisterInterfaceDecoder(reflect.TypeFor[meters](), func(string) (any, error) { return nil, nil })
+		}).To(Panic())
+
+		Expect(func() { urlvalues.RegisterInterfaceDecoder(reflect.TypeFor[distance](), nil) }).To(Panic())
+	})
+})
@@ -0,0 +1,65 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// KeyNormalizer maps a parameter name to the form it is compared in. A Decoder applies it to both the names of struct
+// fields and the keys it receives, so that, for example,
+//
+//	func(key string) string {
+//		return strings.ToLower(strings.ReplaceAll(key, "_", ""))
+//	}
+//
+// lets a field tagged `url:"page_size"` accept PageSize, pagesize and page_size alike.
+type KeyNormalizer func(key string) string
+
+// CaseInsensitive is a KeyNormalizer that ignores the case of parameter names.
+func CaseInsensitive(key string) string {
+	return strings.ToLower(key)
+}
+
+// matchKeys renames the keys of values that normalize to the name or an alias of one of fields to that name or alias.
+// Keys that match no field are dropped, and it is an error for two different keys to match the same field, since there is no
+// way to tell which one the client meant.
+func matchKeys(values url.Values, fields []Field, normalize KeyNormalizer) (url.Values, error) {
+	type target struct {
+		name  string // the name or alias the key is renamed to
+		field string // the name of the field it belongs to
+	}
+
+	names := make(map[string]target, len(fields))
+	for _, f := range fields {
+		if f.In != InQuery {
+			continue
+		}
+
+		// aliases keep their own names, so that the decoder can still tell that one was used
+		for _, name := range append([]string{f.Name}, f.Tag.Aliases...) {
+			if _, ok := names[normalize(name)]; !ok {
+				names[normalize(name)] = target{name: name, field: f.Name}
+			}
+		}
+	}
+
+	matched := make(url.Values, len(values))
+	sources := make(map[string]string, len(values))
+	// keys are visited in order so that errors name them deterministically
+	for _, key := range sortedKeys(values) {
+		t, ok := names[normalize(key)]
+		if !ok {
+			continue
+		}
+
+		if source, seen := sources[t.field]; seen {
+			return nil, fmt.Errorf("parameters %q and %q both match %q", source, key, t.field)
+		}
+
+		sources[t.field] = key
+		matched[t.name] = values[key]
+	}
+
+	return matched, nil
+}
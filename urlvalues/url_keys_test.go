@@ -0,0 +1,71 @@
+package urlvalues_test
+
+import (
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var _ = Describe("Key matching", func() {
+	type page struct {
+		PageSize int    `url:"page_size"`
+		Cursor   string `url:"cursor"`
+	}
+
+	snakeless := func(key string) string {
+		return strings.ToLower(strings.ReplaceAll(key, "_", ""))
+	}
+
+	decode := func(normalize urlvalues.KeyNormalizer, values url.Values) (page, error) {
+		var p page
+		d := urlvalues.NewDecoder(nil)
+		d.SetKeyMatching(normalize)
+		err := d.DecodeValues(values, &p)
+		return p, err
+	}
+
+	It("matches exactly by default", func() {
+		p, err := decode(nil, url.Values{"PAGE_SIZE": {"10"}, "cursor": {"abc"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(page{Cursor: "abc"}))
+	})
+
+	It("ignores case", func() {
+		p, err := decode(urlvalues.CaseInsensitive, url.Values{"PAGE_SIZE": {"10"}, "Cursor": {"abc"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(page{PageSize: 10, Cursor: "abc"}))
+	})
+
+	DescribeTable("applies custom normalizers",
+		func(key string) {
+			p, err := decode(snakeless, url.Values{key: {"25"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.PageSize).To(Equal(25))
+		},
+		Entry("PageSize", "PageSize"),
+		Entry("pagesize", "pagesize"),
+		Entry("page_size", "page_size"),
+	)
+
+	It("rejects keys that match the same field", func() {
+		_, err := decode(snakeless, url.Values{"PageSize": {"10"}, "page_size": {"20"}})
+		Expect(err).To(MatchError(`parameters "PageSize" and "page_size" both match "page_size"`))
+	})
+
+	It("ignores collisions between keys that match no field", func() {
+		p, err := decode(urlvalues.CaseInsensitive, url.Values{"x": {"1"}, "X": {"2"}, "cursor": {"abc"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(page{Cursor: "abc"}))
+	})
+
+	It("is used by Decode", func() {
+		var p page
+		d := urlvalues.NewDecoder(strings.NewReader("Page_Size=5"))
+		d.SetKeyMatching(urlvalues.CaseInsensitive)
+		Expect(d.Decode(&p)).To(Succeed())
+		Expect(p.PageSize).To(Equal(5))
+	})
+})
@@ -3,9 +3,9 @@ package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
 import (
 	"errors"
 	"fmt"
-	"math/big"
 	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -21,8 +21,9 @@ type URLValuesMarshaler interface {
 }
 
 // MarshalURLValues will take an interface{} and attempt to serialize it into a url.Values object. The argument
-// i must be a struct, map[string]any, URLValuesMarshaler or a pointer thereto. If using a struct, the
-// value names can be controlled by the "url" struct tag. For example, given the struct
+// i must be a struct, a map with string keys (such as map[string]any or map[string][]int), URLValuesMarshaler or a
+// pointer thereto. If using a struct, the value names can be controlled by the "url" struct tag. For example, given
+// the struct
 //
 //		type Example struct {
 //			MyStringValue  string    `url:"mystring"`
@@ -57,206 +58,238 @@ func MarshalURLValues(i any) (url.Values, error) {
 		return u.MarshalURLValues()
 	}
 
-	if i == nil {
-		return url.Values{}, errors.New("value cannot be nil")
+	values := url.Values{}
+	if err := marshalTo(i, false, emitToValues(values), encodeOptions{}); err != nil {
+		return url.Values{}, err
 	}
 
-	values := url.Values{}
+	return values, nil
+}
+
+// emitFunc receives each key/value pair a marshaler produces, in order. If add is false, the value replaces any
+// values previously emitted for key, mirroring url.Values.Set; otherwise it is appended, mirroring url.Values.Add.
+type emitFunc func(key, value string, add bool) error
+
+func emitToValues(values url.Values) emitFunc {
+	return func(key, value string, add bool) error {
+		if add {
+			values.Add(key, value)
+		} else {
+			values.Set(key, value)
+		}
+
+		return nil
+	}
+}
+
+// encodeOptions are the settings of an Encoder that change which pairs are produced. The zero value gives the
+// behavior of MarshalURLValues.
+type encodeOptions struct {
+	fieldOptions
+	// formats holds the "urlformat" of map values, by key
+	formats map[string]string
+}
+
+// marshalTo emits the pairs for i. Structs are emitted in field order; map keys are emitted in sorted order if
+// sorted is true, and in map iteration order otherwise.
+func marshalTo(i any, sorted bool, emit emitFunc, opts encodeOptions) error {
+	if u, ok := i.(URLValuesMarshaler); ok {
+		values, err := u.MarshalURLValues()
+		if err != nil {
+			return err
+		}
+
+		for _, k := range sortedKeys(values) {
+			for _, v := range values[k] {
+				if err := emit(k, v, true); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if i == nil {
+		return errors.New("value cannot be nil")
+	}
 
 	vo := reflect.ValueOf(i)
 	if m, ok := i.(map[string]any); ok {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
 
-		for k, v := range m {
-			if err := setValueFromMap(&values, k, v); err != nil {
-				return url.Values{}, err
+		if sorted {
+			slices.Sort(keys)
+		}
+
+		for _, k := range keys {
+			if err := setValueFromMap(emit, k, m[k], opts); err != nil {
+				return err
 			}
 		}
 
-		return values, nil
+		return nil
 	}
 
 	t := reflect.TypeOf(i)
 	if t.Kind() == reflect.Struct {
-		if err := setValuesFromStruct(&values, i); err != nil {
-			return url.Values{}, err
-		}
+		return setValuesFromStruct(emit, i, opts)
+	}
 
-		return values, nil
+	if isStringKeyedMap(t) {
+		return setValuesFromTypedMap(emit, vo, sorted, opts)
 	}
 
 	if t.Kind() == reflect.Pointer {
 		if vo.IsNil() {
-			return url.Values{}, errors.New("value cannot be nil")
+			return errors.New("value cannot be nil")
 		}
 
 		if t.Elem().Kind() == reflect.Struct {
-			if err := setValuesFromStructPointer(&values, i); err != nil {
-				return url.Values{}, err
-			}
-
-			return values, nil
+			return setValuesFromStructPointer(emit, i, opts)
 		}
 	}
 
-	return url.Values{}, errors.New("argument must be a map[string]any, struct, or non-nil pointer to a struct")
+	return errors.New("argument must be a map with string keys, struct, or non-nil pointer to a struct")
 }
 
-func setValueFromMap(vals *url.Values, key string, val any) error {
-	if vals == nil {
-		return errors.New("vals cannot be nil")
+func sortedKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
 	}
 
-	rv := reflect.ValueOf(val)
+	slices.Sort(keys)
+	return keys
+}
 
-	if rv.Kind() == reflect.Pointer {
-		rv = rv.Elem()
-	}
+// isStringKeyedMap reports whether t is a map type whose keys are strings, e.g. map[string]int.
+func isStringKeyedMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
 
-	if rv.Kind() == reflect.Array || rv.Kind() == reflect.Slice {
-		for i := 0; i < rv.Len(); i++ {
-			s, err := stringFromValue(rv.Index(i), rv.Index(i).Type(), "")
-			if err != nil {
-				return err
-			}
+func setValuesFromTypedMap(emit emitFunc, m reflect.Value, sorted bool, opts encodeOptions) error {
+	keys := m.MapKeys()
+	if sorted {
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+	}
 
-			vals.Add(key, s)
-		}
-	} else {
-		s, err := stringFromConcrete(val)
-		if err != nil {
+	for _, k := range keys {
+		if err := setValueFromMap(emit, k.String(), m.MapIndex(k).Interface(), opts); err != nil {
 			return err
 		}
-
-		vals.Set(key, s)
 	}
 
 	return nil
 }
 
-func setValuesFromStruct(values *url.Values, a any) error {
-	t := reflect.TypeOf(a)
-	v := reflect.ValueOf(a)
+func setValueFromMap(emit emitFunc, key string, val any, opts encodeOptions) error {
+	if emit == nil {
+		return errors.New("emit cannot be nil")
+	}
 
-	for i := 0; i < t.NumField(); i++ {
-		sf := t.Field(i)
-		if !sf.IsExported() {
-			continue
-		}
+	if val == nil {
+		return nil
+	}
 
-		fv := v.Field(i)
-
-		key := sf.Name
-		omit := false
-		join := ""
-		tagString, ok := sf.Tag.Lookup("url")
-		if ok {
-			tag, err := parseTag(tagString)
-			if err != nil {
-				if errors.Is(err, errSkip) {
-					continue
-				}
+	return emitValue(emit, key, reflect.ValueOf(val), "", opts.formats[key])
+}
 
-				return err
-			}
+func setValuesFromStruct(emit emitFunc, a any, opts encodeOptions) error {
+	v := reflect.ValueOf(a)
+	fields, err := opts.fields(v.Type())
+	if err != nil {
+		return err
+	}
 
-			key = tag.name
-			join = tag.joinString
-			omit = tag.omitEmpty
+	for _, f := range fields {
+		if f.In != InQuery {
+			// path parameters are part of the request path, not its query
+			continue
 		}
 
-		format, ok := sf.Tag.Lookup("urlformat")
-		if !ok {
-			format = ""
+		fv, err := v.FieldByIndexErr(f.StructField.Index)
+		if err != nil {
+			// the field is inside a nil inline struct pointer
+			continue
 		}
 
-		if !fv.IsValid() || (fv.IsZero() && omit) {
+		if !fv.IsValid() || f.Tag.Omits(fv) {
 			continue
 		}
 
-		if fv.Kind() == reflect.Pointer {
-			if !fv.IsValid() || fv.IsNil() {
-				continue
-			}
-			fv = fv.Elem()
+		if err := emitValue(emit, f.Name, fv, f.Tag.Join, f.Format); err != nil {
+			return err
 		}
+	}
 
-		if fv.Kind() == reflect.Array || fv.Kind() == reflect.Slice {
-			if fv.Kind() == reflect.Slice && (!fv.IsValid() || fv.IsNil()) {
-				continue
-			}
-
-			valueStrings := make([]string, 0, fv.Len())
-			for j := 0; j < fv.Len(); j++ {
-				str, err := stringFromValue(fv.Index(j), fv.Index(j).Type(), format)
-				if err != nil {
-					if errors.Is(err, errSkip) {
-						continue
-					}
-					return err
-				}
+	return nil
+}
 
-				if join == "" {
-					values.Add(key, str)
-					continue
-				}
+// emitValue emits the pairs for a single struct field or map value. Struct fields and map values share it so that a
+// value is converted the same way wherever it appears. Lists produce one pair per element, or a single pair joined by
+// join if it is set; nil pointers, nil slices and nil interfaces produce nothing. An interface is emitted by its
+// dynamic value.
+func emitValue(emit emitFunc, key string, v reflect.Value, join, format string) error {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
 
-				valueStrings = append(valueStrings, str)
-			}
+	strs, err := FormatValues(v, format)
+	if err != nil {
+		return err
+	}
 
-			if len(valueStrings) > 0 {
-				values.Set(key, strings.Join(valueStrings, join))
+	if t := v.Type(); join == "" && (isList(t) || t.Kind() == reflect.Pointer && isList(t.Elem())) {
+		for _, str := range strs {
+			if err := emit(key, str, true); err != nil {
+				return err
 			}
-
-			continue
 		}
 
-		str, err := stringFromValue(fv, sf.Type, format)
-		if err != nil {
-			if errors.Is(err, errSkip) {
-				continue
-			}
-			return err
-		}
+		return nil
+	}
 
-		values.Set(key, str)
+	if len(strs) == 0 {
+		return nil
 	}
 
-	return nil
+	return emit(key, strings.Join(strs, join), false)
 }
 
-func setValuesFromStructPointer(values *url.Values, i any) error {
+func setValuesFromStructPointer(emit emitFunc, i any, opts encodeOptions) error {
 	v := reflect.ValueOf(i).Elem()
-	return setValuesFromStruct(values, v.Interface())
-}
-
-func stringFromConcrete(a any) (string, error) {
-	switch concrete := a.(type) {
-	case bool:
-		return strconv.FormatBool(concrete), nil
-	case complex64:
-		return strconv.FormatComplex(complex128(concrete), 'f', -1, 64), nil
-	case complex128:
-		return strconv.FormatComplex(concrete, 'f', -1, 128), nil
-	case float32:
-		return big.NewFloat(float64(concrete)).String(), nil
-	case float64:
-		return big.NewFloat(concrete).String(), nil
-	case time.Duration:
-		return concrete.String(), nil
-	case int, uint, int8, uint8, int16, uint16, int32, uint32, int64, uint64:
-		return fmt.Sprintf("%d", concrete), nil
-	case string:
-		return concrete, nil
-	case time.Time:
-		return concrete.Format(time.RFC3339), nil
-	case error:
-		return concrete.Error(), nil
-	}
-
-	return stringFromValue(reflect.ValueOf(a), reflect.TypeOf(a), "")
+	return setValuesFromStruct(emit, v.Interface(), opts)
 }
 
 func stringFromValue(v reflect.Value, t reflect.Type, format string) (string, error) {
+	if s, ok, err := stringFromCodec(v); ok {
+		return s, err
+	}
+
+	if v.Kind() == reflect.Interface {
+		// interface values are formatted according to their dynamic type
+		if v.IsNil() {
+			return "", errSkip
+		}
+
+		if e, ok := v.Interface().(error); ok {
+			return e.Error(), nil
+		}
+
+		v = v.Elem()
+		t = v.Type()
+
+		if s, ok, err := stringFromCodec(v); ok {
+			return s, err
+		}
+	}
+
 	if !v.IsValid() {
 		if t.Kind() == reflect.Pointer {
 			v = reflect.New(t.Elem())
@@ -276,7 +309,16 @@ func stringFromValue(v reflect.Value, t reflect.Type, format string) (string, er
 			return "", errSkip
 		}
 
+		if e, ok := v.Interface().(error); ok {
+			// errors are usually implemented on pointer receivers
+			return e.Error(), nil
+		}
+
 		v = v.Elem()
+
+		if s, ok, err := stringFromCodec(v); ok {
+			return s, err
+		}
 	}
 
 	i := v.Interface()
@@ -316,6 +358,10 @@ func stringFromValue(v reflect.Value, t reflect.Type, format string) (string, er
 		return d.String(), nil
 	}
 
+	if isCalendarType(v.Type()) {
+		return stringFromCalendar(v, format), nil
+	}
+
 	if e, ok := i.(error); ok {
 		return e.Error(), nil
 	}
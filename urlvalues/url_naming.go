@@ -0,0 +1,75 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy derives a parameter name from the Go name of a field that has no name in its tag. SnakeCase,
+// LowerCamelCase and KebabCase are provided, and any other function with this signature can be passed to
+// Encoder.SetNaming and Decoder.SetNaming. Without a strategy the Go name is used as is.
+type NamingStrategy func(fieldName string) string
+
+// SnakeCase converts a field name to snake_case, keeping acronyms together: PageSize becomes page_size, UserID becomes
+// user_id, UserIDs becomes user_ids and HTTPServerURL becomes http_server_url.
+func SnakeCase(fieldName string) string {
+	return strings.ToLower(strings.Join(splitWords(fieldName), "_"))
+}
+
+// KebabCase converts a field name to kebab-case, keeping acronyms together: HTTPServerURL becomes http-server-url.
+func KebabCase(fieldName string) string {
+	return strings.ToLower(strings.Join(splitWords(fieldName), "-"))
+}
+
+// LowerCamelCase lowercases the first word of a field name, including a leading acronym, and leaves the rest as they
+// are: PageSize becomes pageSize, ID becomes id and HTTPServerURL becomes httpServerURL.
+func LowerCamelCase(fieldName string) string {
+	words := splitWords(fieldName)
+	if len(words) == 0 {
+		return fieldName
+	}
+
+	words[0] = strings.ToLower(words[0])
+	return strings.Join(words, "")
+}
+
+// splitWords splits a Go identifier into words. A word starts at an upper case letter that follows a lower case letter
+// or digit, or at the last upper case letter of an acronym that is followed by a lower case letter, so HTTPServer2Go
+// splits into HTTP, Server2 and Go. A lone lower case s that ends an acronym makes it plural rather than starting a
+// word, so UserIDs splits into User and IDs. Underscores also separate words and are dropped.
+func splitWords(name string) []string {
+	var (
+		words []string
+		start = 0
+		runes = []rune(name)
+	)
+
+	flush := func(end int) {
+		if end > start {
+			words = append(words, string(runes[start:end]))
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case r == '_':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1]) && !isPluralS(runes, i+1)
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush(i)
+				start = i
+			}
+		}
+	}
+
+	flush(len(runes))
+	return words
+}
+
+// isPluralS reports whether runes[i] is an s that ends the word it is in, i.e. is not followed by a lower case letter.
+func isPluralS(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
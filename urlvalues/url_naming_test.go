@@ -0,0 +1,87 @@
+package urlvalues_test
+
+import (
+	"bytes"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var _ = Describe("Naming strategies", func() {
+	DescribeTable("converts field names",
+		func(naming urlvalues.NamingStrategy, name string, expected string) {
+			Expect(naming(name)).To(Equal(expected))
+		},
+		Entry(nil, urlvalues.SnakeCase, "PageSize", "page_size"),
+		Entry(nil, urlvalues.SnakeCase, "UserID", "user_id"),
+		Entry(nil, urlvalues.SnakeCase, "ID", "id"),
+		Entry(nil, urlvalues.SnakeCase, "HTTPServerURL", "http_server_url"),
+		Entry(nil, urlvalues.SnakeCase, "Page2Size", "page2_size"),
+		Entry(nil, urlvalues.SnakeCase, "Already_Snake", "already_snake"),
+		Entry(nil, urlvalues.SnakeCase, "UserIDs", "user_ids"),
+		Entry(nil, urlvalues.SnakeCase, "IDs", "ids"),
+		Entry(nil, urlvalues.SnakeCase, "URLsByHost", "urls_by_host"),
+		Entry(nil, urlvalues.SnakeCase, "APIs2", "apis2"),
+		Entry(nil, urlvalues.SnakeCase, "IDSet", "id_set"),
+		Entry(nil, urlvalues.KebabCase, "HTTPServerURL", "http-server-url"),
+		Entry(nil, urlvalues.KebabCase, "ServerURLs", "server-urls"),
+		Entry(nil, urlvalues.KebabCase, "NoTags", "no-tags"),
+		Entry(nil, urlvalues.LowerCamelCase, "PageSize", "pageSize"),
+		Entry(nil, urlvalues.LowerCamelCase, "ID", "id"),
+		Entry(nil, urlvalues.LowerCamelCase, "HTTPServerURL", "httpServerURL"),
+		Entry(nil, urlvalues.LowerCamelCase, "IDs", "ids"),
+		Entry(nil, urlvalues.LowerCamelCase, "URLsByHost", "urlsByHost"),
+		Entry(nil, urlvalues.LowerCamelCase, "", ""),
+	)
+
+	type query struct {
+		PageSize int
+		UserID   string
+		Explicit string `url:"ExplicitName"`
+		Empty    string `url:",omitempty"`
+	}
+
+	q := query{PageSize: 10, UserID: "u1", Explicit: "x", Empty: "y"}
+	snake := url.Values{"page_size": {"10"}, "user_id": {"u1"}, "ExplicitName": {"x"}, "empty": {"y"}}
+
+	It("names untagged fields on marshal", func() {
+		e := urlvalues.NewEncoder(nil)
+		e.SetNaming(urlvalues.SnakeCase)
+
+		v, err := e.Marshal(q)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(snake))
+
+		var buf bytes.Buffer
+		e = urlvalues.NewEncoder(&buf)
+		e.SetNaming(urlvalues.KebabCase)
+		Expect(e.Encode(&q)).To(Succeed())
+		Expect(buf.String()).To(Equal("page-size=10&user-id=u1&ExplicitName=x&empty=y"))
+	})
+
+	It("names untagged fields on unmarshal", func() {
+		var out query
+		d := urlvalues.NewDecoder(nil)
+		d.SetNaming(urlvalues.SnakeCase)
+		Expect(d.DecodeValues(snake, &out)).To(Succeed())
+		Expect(out).To(Equal(q))
+	})
+
+	It("keeps Go names by default", func() {
+		v, err := urlvalues.MarshalURLValues(q)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(HaveKey("PageSize"))
+		Expect(v).To(HaveKey("Empty"))
+	})
+
+	It("accepts custom strategies", func() {
+		e := urlvalues.NewEncoder(nil)
+		e.SetNaming(func(name string) string { return "x_" + name })
+
+		v, err := e.Marshal(struct{ A int }{A: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"x_A": {"1"}}))
+	})
+})
@@ -0,0 +1,60 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import "reflect"
+
+type isZeroer interface {
+	IsZero() bool
+}
+
+var isZeroerType = reflect.TypeOf((*isZeroer)(nil)).Elem()
+
+// Omits reports whether a struct field with value v is left out under the omit options of t:
+//
+//   - omitempty follows encoding/json, leaving out false, 0, "", nil pointers and interfaces, and slices, maps and
+//     arrays of length zero. Unlike encoding/json it also leaves out structs that are their zero value, such as an
+//     unset time.Time, as it always has
+//   - omitzero leaves out values whose IsZero method returns true, so a time.Time in any location counts, and values
+//     that are their type's zero value if they have no such method
+//   - omitnil leaves out nil pointers, interfaces, slices and maps. MarshalURLValues has no text for nil values and
+//     never writes them, so the option only makes that explicit
+//
+// Sibling encodings use it so that the options mean the same thing everywhere.
+func (t Tag) Omits(v reflect.Value) bool {
+	return (t.OmitEmpty && isEmptyValue(v)) || (t.OmitZero && isZeroValue(v)) || (t.OmitNil && isNilValue(v))
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
+}
+
+func isZeroValue(v reflect.Value) bool {
+	if isNilValue(v) {
+		return true
+	}
+
+	if v.Type().Implements(isZeroerType) {
+		return v.Interface().(isZeroer).IsZero()
+	}
+
+	if reflect.PointerTo(v.Type()).Implements(isZeroerType) {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return p.Interface().(isZeroer).IsZero()
+	}
+
+	return v.IsZero()
+}
+
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+
+	return false
+}
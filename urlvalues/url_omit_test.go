@@ -0,0 +1,84 @@
+package urlvalues_test
+
+import (
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+// level has an IsZero method on its pointer.
+type level int
+
+func (l *level) IsZero() bool {
+	return *l <= 0
+}
+
+var _ = Describe("Omit options", func() {
+	It("parses the options", func() {
+		tag, err := urlvalues.ParseTag("name,omitzero,omitnil")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tag.OmitZero).To(BeTrue())
+		Expect(tag.OmitNil).To(BeTrue())
+		Expect(tag.OmitEmpty).To(BeFalse())
+	})
+
+	It("omits empty values like encoding/json", func() {
+		type empty struct {
+			Str    string    `url:"str,omitempty"`
+			Int    int       `url:"int,omitempty"`
+			Bool   bool      `url:"bool,omitempty"`
+			Slice  []int     `url:"slice,omitempty"`
+			Joined []string  `url:"joined,omitempty,join=','"`
+			Zeros  [2]int    `url:"zeros,omitempty"`
+			None   [0]int    `url:"none,omitempty"`
+			Time   time.Time `url:"time,omitempty"`
+			Ptr    *int      `url:"ptr,omitempty"`
+		}
+
+		v, err := urlvalues.MarshalURLValues(empty{Slice: []int{}, Joined: []string{}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"zeros": {"0", "0"}}))
+	})
+
+	It("omits zero values using IsZero methods", func() {
+		local := time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC).In(time.FixedZone("X", 3600))
+		Expect(local.IsZero()).To(BeTrue())
+
+		type zero struct {
+			Local     time.Time  `url:"local,omitzero"`
+			LocalPtr  *time.Time `url:"local_ptr,omitzero"`
+			Empty     time.Time  `url:"empty,omitempty"`
+			Level     level      `url:"level,omitzero"`
+			Positive  level      `url:"positive,omitzero"`
+			Count     int        `url:"count,omitzero"`
+			Zeros     [2]int     `url:"zeros,omitzero"`
+			EmptyList []int      `url:"list,omitzero"`
+		}
+
+		v, err := urlvalues.MarshalURLValues(zero{
+			Local: local, LocalPtr: &local, Empty: local, Level: -1, Positive: 2, EmptyList: []int{},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{
+			"empty":    {local.Format(time.RFC3339)},
+			"positive": {"2"},
+		}))
+	})
+
+	It("omits nil values", func() {
+		type nilable struct {
+			Ptr   *int  `url:"ptr,omitnil"`
+			Slice []int `url:"slice,omitnil"`
+			Err   error `url:"err,omitnil"`
+			Zero  *int  `url:"zero,omitnil"`
+		}
+
+		zero := 0
+		v, err := urlvalues.MarshalURLValues(nilable{Zero: &zero})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"zero": {"0"}}))
+	})
+})
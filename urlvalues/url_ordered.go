@@ -0,0 +1,123 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Pair is a single parameter.
+type Pair struct {
+	Key   string
+	Value string
+}
+
+// OrderedValues is a list of parameters that, unlike url.Values, remembers the order they were added in. Encode keeps
+// that order, which matters for APIs that are sensitive to parameter order and for request signing schemes that sign
+// the exact query string.
+type OrderedValues []Pair
+
+// MarshalOrderedURLValues works like MarshalURLValues, but returns the parameters in the order they were produced.
+// Struct fields appear in declaration order and the elements of slice fields in slice order. Map keys, and the keys
+// of a URLValuesMarshaler, have no inherent order and are sorted.
+func MarshalOrderedURLValues(i any) (OrderedValues, error) {
+	var o OrderedValues
+	err := marshalTo(i, true, func(key, value string, add bool) error {
+		if add {
+			o.Add(key, value)
+		} else {
+			o.Set(key, value)
+		}
+
+		return nil
+	}, encodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// OrderedFromValues converts values into OrderedValues. The keys are sorted, since a url.Values has no order of its
+// own, and the values of each key keep their order.
+func OrderedFromValues(values url.Values) OrderedValues {
+	o := make(OrderedValues, 0, len(values))
+	for _, k := range sortedKeys(values) {
+		for _, v := range values[k] {
+			o = append(o, Pair{Key: k, Value: v})
+		}
+	}
+
+	return o
+}
+
+// Add appends the key/value pair.
+func (o *OrderedValues) Add(key, value string) {
+	*o = append(*o, Pair{Key: key, Value: value})
+}
+
+// Set replaces the value of the first pair with the given key and removes any other pairs with that key. If there is
+// no such pair, it is appended.
+func (o *OrderedValues) Set(key, value string) {
+	found := false
+	pairs := (*o)[:0]
+	for _, p := range *o {
+		if p.Key != key {
+			pairs = append(pairs, p)
+			continue
+		}
+
+		if !found {
+			found = true
+			pairs = append(pairs, Pair{Key: key, Value: value})
+		}
+	}
+
+	if !found {
+		pairs = append(pairs, Pair{Key: key, Value: value})
+	}
+
+	*o = pairs
+}
+
+// Get returns the first value for the given key, or "" if there is none.
+func (o OrderedValues) Get(key string) string {
+	for _, p := range o {
+		if p.Key == key {
+			return p.Value
+		}
+	}
+
+	return ""
+}
+
+// Values converts o into a url.Values. The order of the values of each key is kept.
+func (o OrderedValues) Values() url.Values {
+	values := make(url.Values, len(o))
+	for _, p := range o {
+		values.Add(p.Key, p.Value)
+	}
+
+	return values
+}
+
+// Encode encodes the pairs in order into "URL encoded" form ("bar=baz&foo=quux"), escaping them the same way
+// url.Values.Encode does.
+func (o OrderedValues) Encode() string {
+	return o.EncodeWith(FormEscaping)
+}
+
+// EncodeWith encodes the pairs in order, escaping them according to escaping.
+func (o OrderedValues) EncodeWith(escaping Escaping) string {
+	var sb strings.Builder
+	for i, p := range o {
+		if i > 0 {
+			sb.WriteByte('&')
+		}
+
+		sb.WriteString(escaping.Escape(p.Key))
+		sb.WriteByte('=')
+		sb.WriteString(escaping.Escape(p.Value))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+package urlvalues_test
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var _ = Describe("OrderedValues", func() {
+	type signed struct {
+		Timestamp int64    `url:"timestamp"`
+		Action    string   `url:"action"`
+		IDs       []int    `url:"id"`
+		Note      string   `url:"note,omitempty"`
+		Filters   []string `url:"filter,join=','"`
+	}
+
+	It("keeps struct declaration order", func() {
+		o, err := urlvalues.MarshalOrderedURLValues(signed{Timestamp: 1, Action: "list items", IDs: []int{3, 1}, Filters: []string{"a", "b"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(o).To(Equal(urlvalues.OrderedValues{
+			{"timestamp", "1"},
+			{"action", "list items"},
+			{"id", "3"},
+			{"id", "1"},
+			{"filter", "a,b"},
+		}))
+		Expect(o.Encode()).To(Equal("timestamp=1&action=list+items&id=3&id=1&filter=a%2Cb"))
+	})
+
+	It("converts to and from url.Values", func() {
+		values := url.Values{"b": {"2", "1"}, "a": {"x"}}
+
+		o := urlvalues.OrderedFromValues(values)
+		Expect(o).To(Equal(urlvalues.OrderedValues{{"a", "x"}, {"b", "2"}, {"b", "1"}}))
+		Expect(o.Encode()).To(Equal(values.Encode()))
+		Expect(o.Values()).To(Equal(values))
+	})
+
+	It("adds, sets, and gets pairs", func() {
+		var o urlvalues.OrderedValues
+		o.Add("z", "1")
+		o.Add("a", "2")
+		o.Add("z", "3")
+		Expect(o.Get("z")).To(Equal("1"))
+
+		o.Set("z", "4")
+		Expect(o).To(Equal(urlvalues.OrderedValues{{"z", "4"}, {"a", "2"}}))
+
+		o.Set("m", "5")
+		Expect(o.Encode()).To(Equal("z=4&a=2&m=5"))
+		Expect(o.Get("missing")).To(BeEmpty())
+	})
+
+	It("fails on the same arguments as MarshalURLValues", func() {
+		_, err := urlvalues.MarshalOrderedURLValues([]int{1})
+		Expect(err).To(HaveOccurred())
+	})
+})
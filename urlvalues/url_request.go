@@ -0,0 +1,91 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// PathValuer is anything that can look up a named path wildcard. *http.Request satisfies this interface when used
+// with the pattern-based routing in http.ServeMux (Go 1.22 and later).
+type PathValuer interface {
+	PathValue(name string) string
+}
+
+// UnmarshalPathValues binds the wildcards exposed by p into the struct pointed to by a. Only fields with a "path"
+// struct tag are considered, e.g.
+//
+//	type GetItem struct {
+//		ID   int64  `path:"id"`
+//		View string `url:"view"`
+//	}
+//
+// Path values are converted with the same rules (and "urlformat" tags) that UnmarshalURLValues uses for query
+// parameters. Empty path values are treated as absent. The other fields of *a are left as they are, so the path can be
+// bound before or after the query; default and required options, which apply to query parameters, are not checked.
+func UnmarshalPathValues(p PathValuer, a any) error {
+	v, err := structPointer(a)
+	if err != nil {
+		return err
+	}
+
+	fields, err := decodeOptions{}.fields(v.Type())
+	if err != nil {
+		return err
+	}
+
+	_, err = setPathValues(v, fields, p)
+	return err
+}
+
+// UnmarshalRequest decodes the path wildcards, query string, and form body of r into the struct pointed to by a.
+// Fields with a "path" tag are bound from r.PathValue. Fields with a "url" tag (or no tag at all) are bound from
+// r.Form, which contains both the query parameters and any application/x-www-form-urlencoded body. A field with both
+// tags prefers the path value when it is non-empty. This lets a single struct describe every input of a handler:
+//
+//	type UpdateItem struct {
+//		ID     int64  `path:"id"`
+//		DryRun bool   `url:"dry_run"`
+//		Name   string `url:"name"`
+//	}
+func UnmarshalRequest(r *http.Request, a any) error {
+	if r == nil {
+		return errors.New("request must not be nil")
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+
+	return unmarshalWithPath(r.Form, r, a)
+}
+
+func unmarshalWithPath(values url.Values, p PathValuer, a any) error {
+	v, err := structPointer(a)
+	if err != nil {
+		return err
+	}
+
+	newStruct, err := unmarshalStruct(values, p, v.Type(), decodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	v.Set(newStruct)
+	return nil
+}
+
+// structPointer returns the struct a points to.
+func structPointer(a any) (reflect.Value, error) {
+	if a == nil {
+		return reflect.Value{}, errors.New("second argument must not be nil")
+	}
+
+	aType := reflect.TypeOf(a)
+	if aType.Kind() != reflect.Pointer || aType.Elem().Kind() != reflect.Struct || reflect.ValueOf(a).IsNil() {
+		return reflect.Value{}, errors.New("second argument must be a non-nil pointer to a struct")
+	}
+
+	return reflect.ValueOf(a).Elem(), nil
+}
@@ -0,0 +1,135 @@
+package urlvalues_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+type pathStruct struct {
+	ID      int64         `path:"id"`
+	Section string        `path:"section" url:"section"`
+	Timeout time.Duration `path:"timeout" urlformat:"int,s"`
+	DryRun  bool          `url:"dry_run"`
+	Name    string        `url:"name"`
+	Skipped string        `path:"-"`
+}
+
+type staticPathValues map[string]string
+
+func (s staticPathValues) PathValue(name string) string {
+	return s[name]
+}
+
+var _ = Describe("Path Values", func() {
+	serve := func(pattern string, req *http.Request) (pathStruct, error) {
+		var (
+			p   pathStruct
+			err error
+		)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			err = urlvalues.UnmarshalRequest(r, &p)
+		})
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+
+		return p, err
+	}
+
+	It("binds path values from a PathValuer", func() {
+		var p pathStruct
+		err := urlvalues.UnmarshalPathValues(staticPathValues{"id": "42", "timeout": "30", "section": "a"}, &p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(pathStruct{ID: 42, Section: "a", Timeout: 30 * time.Second}))
+	})
+
+	It("binds path, query, and form values from a request", func() {
+		body := strings.NewReader(url.Values{"name": {"widget"}}.Encode())
+		req := httptest.NewRequest(http.MethodPost, "/items/7?dry_run=true&section=q", body)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		p, err := serve("POST /items/{id}", req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(pathStruct{ID: 7, Section: "q", DryRun: true, Name: "widget"}))
+	})
+
+	It("prefers the path value over the query value", func() {
+		req := httptest.NewRequest(http.MethodGet, "/items/7/p?section=q", nil)
+
+		p, err := serve("GET /items/{id}/{section}", req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.Section).To(Equal("p"))
+	})
+
+	It("does not bind path-only fields from the query", func() {
+		req := httptest.NewRequest(http.MethodGet, "/items?ID=3", nil)
+
+		p, err := serve("GET /items", req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.ID).To(BeZero())
+	})
+
+	It("does not marshal path-only fields", func() {
+		vals, err := urlvalues.MarshalURLValues(pathStruct{ID: 3, Section: "s", Name: "n"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vals.Encode()).To(Equal("dry_run=false&name=n&section=s"))
+	})
+
+	Context("with required and defaulted query parameters", func() {
+		type search struct {
+			ID    int64  `path:"id"`
+			Query string `url:"q,required"`
+			Depth int    `url:"d,default='5'"`
+		}
+
+		It("binds path values onto the query values already decoded", func() {
+			var s search
+			Expect(urlvalues.UnmarshalURLValues(url.Values{"q": {"hello"}, "d": {"9"}}, &s)).To(Succeed())
+			Expect(urlvalues.UnmarshalPathValues(staticPathValues{"id": "7"}, &s)).To(Succeed())
+			Expect(s).To(Equal(search{ID: 7, Query: "hello", Depth: 9}))
+		})
+
+		It("binds path values without checking the query options", func() {
+			var s search
+			Expect(urlvalues.UnmarshalPathValues(staticPathValues{"id": "7"}, &s)).To(Succeed())
+			Expect(s).To(Equal(search{ID: 7}))
+		})
+		It("checks them when decoding a request", func() {
+			var s search
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /search/{id}", func(w http.ResponseWriter, r *http.Request) {
+				s = search{}
+				if err := urlvalues.UnmarshalRequest(r, &s); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			})
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search/7?q=hello", nil))
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(s).To(Equal(search{ID: 7, Query: "hello", Depth: 5}))
+
+			rec = httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search/7", nil))
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+	})
+
+	It("fails on unparseable path values", func() {
+		var p pathStruct
+		Expect(urlvalues.UnmarshalPathValues(staticPathValues{"id": "abc"}, &p)).To(HaveOccurred())
+	})
+
+	It("fails on a non-struct pointer", func() {
+		var s string
+		Expect(urlvalues.UnmarshalPathValues(staticPathValues{}, &s)).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,162 @@
+package urlvalues // import "go.gideaworx.io/go-encoding/urlvalues"
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	monthType   = reflect.TypeFor[time.Month]()
+	weekdayType = reflect.TypeFor[time.Weekday]()
+)
+
+// The standard library value types below are converted by codecs registered here, so that they behave like any type
+// registered with RegisterCodec: they are single values (even net.IP, which is a byte slice), pointers to them and
+// slices of them work, and registering one of them again replaces the built-in conversion. A pointer type such as
+// *url.URL is handled through its element type.
+func init() {
+	RegisterCodec(textEncoder[netip.Addr], func(s string) (netip.Addr, error) {
+		var a netip.Addr
+		err := a.UnmarshalText([]byte(s))
+		return a, err
+	})
+	RegisterCodec(textEncoder[netip.Prefix], func(s string) (netip.Prefix, error) {
+		var p netip.Prefix
+		err := p.UnmarshalText([]byte(s))
+		return p, err
+	})
+	RegisterCodec(textEncoder[net.IP], func(s string) (net.IP, error) {
+		var ip net.IP
+		err := ip.UnmarshalText([]byte(s))
+		return ip, err
+	})
+	RegisterCodec(func(u url.URL) (string, error) {
+		return u.String(), nil
+	}, func(s string) (url.URL, error) {
+		u, err := url.Parse(s)
+		if err != nil {
+			return url.URL{}, err
+		}
+
+		return *u, nil
+	})
+	RegisterCodec(func(a mail.Address) (string, error) {
+		if a == (mail.Address{}) {
+			return "", nil
+		}
+
+		return a.String(), nil
+	}, func(s string) (mail.Address, error) {
+		if s == "" {
+			return mail.Address{}, nil
+		}
+
+		a, err := mail.ParseAddress(s)
+		if err != nil {
+			return mail.Address{}, err
+		}
+
+		return *a, nil
+	})
+	RegisterCodec(func(re regexp.Regexp) (string, error) {
+		return re.String(), nil
+	}, func(s string) (regexp.Regexp, error) {
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return regexp.Regexp{}, err
+		}
+
+		return *re, nil
+	})
+	RegisterCodec(func(i big.Int) (string, error) {
+		return i.String(), nil
+	}, func(s string) (big.Int, error) {
+		var i big.Int
+		if _, ok := i.SetString(s, 10); !ok {
+			return big.Int{}, fmt.Errorf("invalid integer %q", s)
+		}
+
+		return i, nil
+	})
+	RegisterCodec(func(f big.Float) (string, error) {
+		return f.Text('g', -1), nil
+	}, func(s string) (big.Float, error) {
+		var f big.Float
+		if _, ok := f.SetString(s); !ok {
+			return big.Float{}, fmt.Errorf("invalid number %q", s)
+		}
+
+		return f, nil
+	})
+	RegisterCodec(func(r big.Rat) (string, error) {
+		return r.RatString(), nil
+	}, func(s string) (big.Rat, error) {
+		var r big.Rat
+		if _, ok := r.SetString(s); !ok {
+			return big.Rat{}, fmt.Errorf("invalid rational number %q", s)
+		}
+
+		return r, nil
+	})
+}
+
+// textEncoder encodes a value with its MarshalText method, which unlike String gives "" for the zero value of the
+// net and net/netip types.
+func textEncoder[T interface{ MarshalText() ([]byte, error) }](v T) (string, error) {
+	b, err := v.MarshalText()
+	return string(b), err
+}
+
+// isCalendarType reports whether t is time.Month or time.Weekday.
+func isCalendarType(t reflect.Type) bool {
+	return t == monthType || t == weekdayType
+}
+
+// calendarRange returns the numbers of the first and last named time.Month or time.Weekday, depending on t.
+func calendarRange(t reflect.Type) (int64, int64) {
+	if t == weekdayType {
+		return int64(time.Sunday), int64(time.Saturday)
+	}
+
+	return int64(time.January), int64(time.December)
+}
+
+// stringFromCalendar formats a time.Month or time.Weekday by its number, as it would any other integer, or by its
+// English name if format is "name" and the value has one (the zero time.Month does not).
+func stringFromCalendar(v reflect.Value, format string) string {
+	first, last := calendarRange(v.Type())
+	if n := v.Int(); !strings.EqualFold(format, "name") || n < first || n > last {
+		return strconv.FormatInt(n, 10)
+	}
+
+	return v.Interface().(fmt.Stringer).String()
+}
+
+// calendarFromString parses a time.Month or time.Weekday from its number or from its English name, in any case, whatever
+// the format.
+func calendarFromString(s string, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		v.SetInt(n)
+		return v, nil
+	}
+
+	first, last := calendarRange(t)
+	for n := first; n <= last; n++ {
+		v.SetInt(n)
+		if strings.EqualFold(stringFromCalendar(v, "name"), s) {
+			return v, nil
+		}
+	}
+
+	return reflect.Zero(t), fmt.Errorf("invalid %s %q", t, s)
+}
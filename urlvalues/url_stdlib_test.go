@@ -0,0 +1,148 @@
+package urlvalues_test
+
+import (
+	"math/big"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+type network struct {
+	Addr     netip.Addr     `url:"addr"`
+	Prefix   *netip.Prefix  `url:"prefix"`
+	Servers  []net.IP       `url:"server"`
+	Callback *url.URL       `url:"callback"`
+	Contact  *mail.Address  `url:"contact"`
+	Pattern  *regexp.Regexp `url:"pattern"`
+	Month    time.Month     `url:"month" urlformat:"name"`
+	Days     []time.Weekday `url:"day,join=','" urlformat:"name"`
+	Start    time.Weekday   `url:"start"`
+	Total    big.Int        `url:"total"`
+	Scale    *big.Float     `url:"scale"`
+	Shares   []*big.Rat     `url:"share"`
+}
+
+var _ = Describe("Standard library types", func() {
+	callback, _ := url.Parse("https://example.com/hook?x=1")
+	prefix := netip.MustParsePrefix("10.0.0.0/8")
+
+	n := network{
+		Addr:     netip.MustParseAddr("2001:db8::1"),
+		Prefix:   &prefix,
+		Servers:  []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("192.0.2.2")},
+		Callback: callback,
+		Contact:  &mail.Address{Name: "Ops", Address: "ops@example.com"},
+		Pattern:  regexp.MustCompile(`^[a-z]+$`),
+		Month:    time.March,
+		Days:     []time.Weekday{time.Monday, time.Friday},
+		Start:    time.Sunday,
+		Total:    *big.NewInt(0).Lsh(big.NewInt(1), 70),
+		Scale:    big.NewFloat(0.125),
+		Shares:   []*big.Rat{big.NewRat(1, 3), big.NewRat(4, 2)},
+	}
+
+	expected := url.Values{
+		"addr":     {"2001:db8::1"},
+		"prefix":   {"10.0.0.0/8"},
+		"server":   {"192.0.2.1", "192.0.2.2"},
+		"callback": {"https://example.com/hook?x=1"},
+		"contact":  {`"Ops" <ops@example.com>`},
+		"pattern":  {"^[a-z]+$"},
+		"month":    {"March"},
+		"day":      {"Monday,Friday"},
+		"start":    {"0"},
+		"total":    {"1180591620717411303424"},
+		"scale":    {"0.125"},
+		"share":    {"1/3", "2"},
+	}
+
+	It("marshals them as single values", func() {
+		vals, err := urlvalues.MarshalURLValues(n)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vals).To(Equal(expected))
+	})
+
+	It("marshals them as map values", func() {
+		vals, err := urlvalues.MarshalURLValues(map[string]any{"ip": net.ParseIP("::1"), "total": big.NewInt(-5)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vals).To(Equal(url.Values{"ip": {"::1"}, "total": {"-5"}}))
+	})
+
+	It("unmarshals what it marshals", func() {
+		var decoded network
+		Expect(urlvalues.UnmarshalURLValues(expected, &decoded)).To(Succeed())
+
+		Expect(decoded.Addr).To(Equal(n.Addr))
+		Expect(*decoded.Prefix).To(Equal(*n.Prefix))
+		Expect(decoded.Servers).To(HaveLen(2))
+		Expect(decoded.Servers[1].Equal(n.Servers[1])).To(BeTrue())
+		Expect(decoded.Callback.String()).To(Equal(callback.String()))
+		Expect(*decoded.Contact).To(Equal(*n.Contact))
+		Expect(decoded.Pattern.MatchString("abc")).To(BeTrue())
+		Expect(decoded.Month).To(Equal(time.March))
+		Expect(decoded.Days).To(Equal(n.Days))
+		Expect(decoded.Start).To(Equal(time.Sunday))
+		Expect(decoded.Total.Cmp(&n.Total)).To(BeZero())
+		Expect(decoded.Scale.Cmp(n.Scale)).To(BeZero())
+		Expect(decoded.Shares).To(HaveLen(2))
+		Expect(decoded.Shares[0].Cmp(n.Shares[0])).To(BeZero())
+		Expect(decoded.Shares[1].Cmp(n.Shares[1])).To(BeZero())
+	})
+
+	It("writes months and weekdays as numbers unless they are formatted by name", func() {
+		vals, err := urlvalues.MarshalURLValues(struct {
+			Month time.Month     `url:"month"`
+			Days  []time.Weekday `url:"day"`
+		}{time.March, []time.Weekday{time.Monday}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vals).To(Equal(url.Values{"month": {"3"}, "day": {"1"}}))
+	})
+
+	It("accepts months and weekdays by number or in any case", func() {
+		var decoded network
+		Expect(urlvalues.UnmarshalURLValues(url.Values{"month": {"12"}, "day": {"tuesday,SATURDAY"}}, &decoded)).
+			To(Succeed())
+		Expect(decoded.Month).To(Equal(time.December))
+		Expect(decoded.Days).To(Equal([]time.Weekday{time.Tuesday, time.Saturday}))
+	})
+
+	It("marshals zero values", func() {
+		vals, err := urlvalues.MarshalURLValues(network{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vals.Get("addr")).To(BeEmpty())
+		Expect(vals.Get("month")).To(Equal("0"))
+		Expect(vals.Get("total")).To(Equal("0"))
+		Expect(vals).NotTo(HaveKey("prefix"))
+	})
+
+	DescribeTable("rejects invalid values",
+		func(key, value string) {
+			var decoded network
+			Expect(urlvalues.UnmarshalURLValues(url.Values{key: {value}}, &decoded)).NotTo(Succeed())
+		},
+		Entry("netip.Addr", "addr", "300.1.1.1"),
+		Entry("netip.Prefix", "prefix", "10.0.0.0"),
+		Entry("net.IP", "server", "localhost"),
+		Entry("url.URL", "callback", "http://[::1"),
+		Entry("mail.Address", "contact", "not an address"),
+		Entry("regexp.Regexp", "pattern", "(unclosed"),
+		Entry("time.Month", "month", "Smarch"),
+		Entry("time.Weekday", "day", "Funday"),
+		Entry("big.Int", "total", "1.5"),
+		Entry("big.Float", "scale", "one"),
+		Entry("big.Rat", "share", "1/0"),
+	)
+
+	It("reports the types as codecs", func() {
+		Expect(urlvalues.HasCodec(reflect.TypeOf(net.IP{}))).To(BeTrue())
+		Expect(urlvalues.HasCodec(reflect.TypeOf(big.Int{}))).To(BeTrue())
+	})
+})
@@ -0,0 +1,84 @@
+package urlvalues_test
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.gideaworx.io/go-encoding/urlvalues"
+)
+
+var _ = Describe("Tag names", func() {
+	type dto struct {
+		Name    string `json:"name"`
+		Count   int    `json:"count,omitempty,string"`
+		Secret  string `json:"-"`
+		Dash    string `json:"-,"`
+		Query   string `url:"q" json:"query"`
+		Plain   string
+		Comment string `json:"comment,omitempty"`
+	}
+
+	d := dto{Name: "n", Secret: "s", Dash: "d", Query: "find", Plain: "p"}
+
+	It("falls back to the json tag", func() {
+		e := urlvalues.NewEncoder(nil)
+		e.SetTagNames("url", "json")
+
+		v, err := e.Marshal(d)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"name": {"n"}, "-": {"d"}, "q": {"find"}, "Plain": {"p"}}))
+	})
+
+	It("uses only the url tag by default", func() {
+		v, err := urlvalues.MarshalURLValues(d)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(HaveKey("Name"))
+		Expect(v).To(HaveKey("Secret"))
+		Expect(v).To(HaveKey("q"))
+	})
+
+	It("decodes with the fallback tags", func() {
+		var out dto
+		dec := urlvalues.NewDecoder(nil)
+		dec.SetTagNames("url", "json")
+		Expect(dec.DecodeValues(url.Values{
+			"name": {"n"}, "count": {"3"}, "Secret": {"s"}, "q": {"find"}, "query": {"ignored"},
+		}, &out)).To(Succeed())
+		Expect(out).To(Equal(dto{Name: "n", Count: 3, Query: "find"}))
+	})
+
+	It("can prefer another tag", func() {
+		e := urlvalues.NewEncoder(nil)
+		e.SetTagNames("json", "url")
+
+		v, err := e.Marshal(dto{Query: "find"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(HaveKeyWithValue("query", []string{"find"}))
+
+		e.SetTagNames()
+		v, err = e.Marshal(dto{Query: "find"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(HaveKeyWithValue("q", []string{"find"}))
+	})
+
+	It("does not bind path parameters through a fallback tag", func() {
+		type route struct {
+			ID    int    `path:"id" json:"id"`
+			Org   string `path:"org" url:"org" json:"organization"`
+			Query string `json:"q"`
+		}
+
+		var out route
+		dec := urlvalues.NewDecoder(nil)
+		dec.SetTagNames("url", "json")
+		Expect(dec.DecodeValues(url.Values{"id": {"7"}, "org": {"acme"}, "q": {"find"}}, &out)).To(Succeed())
+		Expect(out).To(Equal(route{Org: "acme", Query: "find"}))
+
+		e := urlvalues.NewEncoder(nil)
+		e.SetTagNames("url", "json")
+		v, err := e.Marshal(route{ID: 7, Org: "acme", Query: "find"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(url.Values{"org": {"acme"}, "q": {"find"}}))
+	})
+})
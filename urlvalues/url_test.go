@@ -328,6 +328,24 @@ var _ = Describe("URL Values Marshaling and Unmarshaling", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(x).To(BeEquivalentTo(s))
 			})
+
+			It("Unmarshals times using their format", func() {
+				type timeFormat struct {
+					Date time.Time `url:"date" urlformat:"2006-01-02"`
+				}
+
+				v := url.Values{}
+				v.Set("date", "2021-02-18")
+
+				var x timeFormat
+				err := urlvalues.UnmarshalURLValues(v, &x)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(x.Date).To(Equal(time.Date(2021, time.February, 18, 0, 0, 0, 0, time.UTC)))
+
+				vals, err := urlvalues.MarshalURLValues(x)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vals).To(Equal(v))
+			})
 		})
 
 		Describe("Error conditions", func() {
@@ -30,18 +30,33 @@ type URLValuesUnmarshaler interface {
 //
 // * if none of the above are true, s will be return unparsed
 //
+// These rules are InferDefault; Decoder.SetInference selects others. Any other map with string keys, such as
+// *map[string]int or *map[string][]string, has each parameter parsed into the map's value type, using the first value
+// of the parameter unless the value type is a slice or array.
+//
 // if a parameter has multiple values, the map key will contain an instance of []any with each slice element parsed
 // according to the above rules. If the argument is a *struct, each parameter will be deserialized, if possible,
 // to the corresponding struct field's type, using the field's "url" struct tag to map the parameter name to field
 // name, if present. Unexported fields and fields with struct tag `url:"-"` are skipped. If the struct tag ends in
-// ',omitempty' and the value is the type's zero value, it will not be explicitly set.
+// ',omitempty' and the value is the type's zero value, it will not be explicitly set. A tag may list other accepted
+// names after the first, separated by '|', e.g. `url:"limit|page_size"`; the first name present in values is used.
+// A parameter that is missing from values is parsed from the tag's default='...' option if it has one, splitting
+// lists on commas unless the tag has a join option, and is an error if the tag has the required option instead.
+//
+// Fields of interface types are decoded by the function registered with RegisterInterfaceDecoder. Fields of type any
+// with no registered decoder are parsed like the values of a map[string]any.
 func UnmarshalURLValues(values url.Values, a any) error {
+	return unmarshalValues(values, a, decodeOptions{})
+}
+
+// unmarshalValues is UnmarshalURLValues with the options of a Decoder.
+func unmarshalValues(values url.Values, a any, opts decodeOptions) error {
 	if a == nil {
 		return errors.New("second argument must not be nil")
 	}
 
 	if m, ok := a.(*map[string]any); ok {
-		newMap := unmarshalMap(values)
+		newMap := unmarshalMap(values, opts.inference())
 
 		*m = newMap
 		return nil
@@ -53,7 +68,17 @@ func UnmarshalURLValues(values url.Values, a any) error {
 			return um.UnmarshalURLValues(values)
 		}
 
-		newStruct, err := unmarshalStruct(values, aType.Elem())
+		if isStringKeyedMap(aType.Elem()) {
+			newMap, err := unmarshalTypedMap(values, aType.Elem())
+			if err != nil {
+				return err
+			}
+
+			reflect.ValueOf(a).Elem().Set(newMap)
+			return nil
+		}
+
+		newStruct, err := unmarshalStruct(values, nil, aType.Elem(), opts)
 		if err != nil {
 			return err
 		}
@@ -62,19 +87,19 @@ func UnmarshalURLValues(values url.Values, a any) error {
 		return nil
 	}
 
-	return errors.New("second argument must be a non-nil pointer to a map[string]any or struct")
+	return errors.New("second argument must be a non-nil pointer to a map with string keys or struct")
 }
 
-func unmarshalMap(values url.Values) map[string]any {
+func unmarshalMap(values url.Values, infer TypeInference) map[string]any {
 	m := make(map[string]any)
 
 	for k, vslice := range values {
 		if len(vslice) == 1 {
-			m[k] = fromStringToAny(vslice[0])
+			m[k] = infer(vslice[0])
 		} else {
 			aslice := make([]any, 0, len(vslice))
 			for _, s := range vslice {
-				aslice = append(aslice, fromStringToAny(s))
+				aslice = append(aslice, infer(s))
 			}
 			m[k] = aslice
 		}
@@ -83,48 +108,82 @@ func unmarshalMap(values url.Values) map[string]any {
 	return m
 }
 
-func unmarshalStruct(values url.Values, structType reflect.Type) (reflect.Value, error) {
+// unmarshalTypedMap decodes values into a map[K]V whose key kind is string. Each value is parsed like a struct field
+// of type V without a format or join, so a scalar V takes the first value of its parameter.
+func unmarshalTypedMap(values url.Values, mapType reflect.Type) (reflect.Value, error) {
+	m := reflect.MakeMapWithSize(mapType, len(values))
+	for k, vslice := range values {
+		v, err := fromStringsToValue(vslice, mapType.Elem(), "", "")
+		if err != nil {
+			if errors.Is(err, errSkip) {
+				continue
+			}
+
+			return m, fmt.Errorf("parameter %s: %w", k, err)
+		}
+
+		m.SetMapIndex(reflect.ValueOf(k).Convert(mapType.Key()), v)
+	}
+
+	return m, nil
+}
+
+func unmarshalStruct(values url.Values, pathValues PathValuer, structType reflect.Type, opts decodeOptions) (reflect.Value, error) {
 	if structType.Kind() != reflect.Struct {
 		return reflect.Zero(structType), errors.New("structType must be struct")
 	}
 
+	fields, err := opts.fields(structType)
+	if err != nil {
+		return reflect.Zero(structType), err
+	}
+
+	if opts.normalizeKey != nil {
+		if values, err = matchKeys(values, fields, opts.normalizeKey); err != nil {
+			return reflect.Zero(structType), err
+		}
+	}
+
 	retValue := reflect.New(structType).Elem()
-	for i := 0; i < structType.NumField(); i++ {
-		structField := structType.Field(i)
-		structFieldValue := retValue.Field(i)
-		if !structField.IsExported() {
+	fromPath, err := setPathValues(retValue, fields, pathValues)
+	if err != nil {
+		return reflect.Zero(structType), err
+	}
+
+	for _, f := range fields {
+		structField := f.StructField
+		parameterName, omitEmpty, join, format := f.Name, f.Tag.OmitEmpty, f.Tag.Join, f.Format
+
+		if f.In == InPath {
 			continue
 		}
 
-		parameterName := structField.Name
-		omitEmpty := false
-		join := ""
-		tagString, ok := structField.Tag.Lookup("url")
-		if ok {
-			tag, err := parseTag(tagString)
-			if err != nil {
-				if errors.Is(err, errSkip) {
-					continue
-				}
+		if fromPath[fmt.Sprint(structField.Index)] {
+			// a non-empty path value takes priority over the query
+			continue
+		}
 
-				return reflect.Zero(structType), err
+		key, ok := lookupKey(values, f)
+		if !ok && !f.Tag.HasDefault {
+			if f.Tag.Required {
+				return reflect.Zero(structType), fmt.Errorf("required parameter %s is missing", parameterName)
 			}
 
-			parameterName = tag.name
-			join = tag.joinString
-			omitEmpty = tag.omitEmpty
+			continue
 		}
 
-		format, ok := structField.Tag.Lookup("urlformat")
-		if !ok {
-			format = ""
+		if ok && key != parameterName && opts.onAlias != nil {
+			opts.onAlias(parameterName, key)
 		}
 
-		if !values.Has(parameterName) {
-			continue
+		fieldValues := values[key]
+		if !ok {
+			if fieldValues = defaultValues(f); len(fieldValues) == 0 {
+				continue
+			}
 		}
 
-		parsedValue, err := fromStringsToValue(values[parameterName], structField.Type, format, join)
+		parsedValue, err := fromStringsToValue(fieldValues, structField.Type, format, join)
 		if err != nil {
 			return parsedValue, err
 		}
@@ -133,6 +192,7 @@ func unmarshalStruct(values url.Values, structType reflect.Type) (reflect.Value,
 			continue
 		}
 
+		structFieldValue := settableField(retValue, structField.Index)
 		if !structFieldValue.CanSet() {
 			return reflect.Zero(structType), fmt.Errorf("cannot set field %s", structField.Name)
 		}
@@ -147,6 +207,89 @@ func unmarshalStruct(values url.Values, structType reflect.Type) (reflect.Value,
 	return retValue, nil
 }
 
+// setPathValues sets the InPath fields of the struct v from the non-empty values of pathValues, which may be nil. It
+// returns the index paths, formatted with fmt.Sprint, of the fields it set.
+func setPathValues(v reflect.Value, fields []Field, pathValues PathValuer) (map[string]bool, error) {
+	set := make(map[string]bool)
+	if pathValues == nil {
+		return set, nil
+	}
+
+	for _, f := range fields {
+		if f.In != InPath {
+			continue
+		}
+
+		pathValue := pathValues.PathValue(f.Name)
+		if pathValue == "" {
+			continue
+		}
+
+		parsedValue, err := fromStringsToValue([]string{pathValue}, f.StructField.Type, f.Format, "")
+		if err != nil {
+			return set, err
+		}
+
+		settableField(v, f.StructField.Index).Set(parsedValue)
+		set[fmt.Sprint(f.StructField.Index)] = true
+	}
+
+	return set, nil
+}
+
+// defaultValues returns the values of the default option of f. A list without a join option is split on commas, and
+// an empty default leaves it empty.
+func defaultValues(f Field) []string {
+	t := f.StructField.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if !isList(t) || f.Tag.Join != "" {
+		return []string{f.Tag.Default}
+	}
+
+	if f.Tag.Default == "" {
+		return nil
+	}
+
+	return strings.Split(f.Tag.Default, ",")
+}
+
+// settableField returns the field of v at index, allocating any nil struct pointers on the way, which only exist when
+// inline fields are pointers.
+func settableField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+// lookupKey returns the key f is present under in values: its name if that is present, and otherwise the first of its
+// aliases that is.
+func lookupKey(values url.Values, f Field) (string, bool) {
+	if values.Has(f.Name) {
+		return f.Name, true
+	}
+
+	for _, alias := range f.Tag.Aliases {
+		if values.Has(alias) {
+			return alias, true
+		}
+	}
+
+	return "", false
+}
+
 // if s can be parsed as a bool, it will return a bool
 // if s can be parsed as a real number, it will return a float64
 // if s can be parsed as a complex number, it will return a complex128
@@ -189,12 +332,11 @@ func fromStringsToValue(values []string, fieldType reflect.Type, format string,
 		retVal = reflect.New(fieldType.Elem())
 	}
 
-	if (retType.Kind() == reflect.Slice || retType.Kind() == reflect.Array) &&
-		len(values) == 1 && join != "" {
+	if isList(retType) && len(values) == 1 && join != "" {
 		values = strings.Split(values[0], join)
 	}
 
-	if retType.Kind() == reflect.Slice {
+	if isList(retType) && retType.Kind() == reflect.Slice {
 		sliceVal := reflect.MakeSlice(retType, len(values), len(values)+2)
 		if !sliceVal.Type().AssignableTo(retType) {
 			return reflect.Zero(retType), fmt.Errorf("cannot assign %s to %s", sliceVal.Type(), retType)
@@ -202,7 +344,7 @@ func fromStringsToValue(values []string, fieldType reflect.Type, format string,
 		retVal.Elem().Set(sliceVal)
 	}
 
-	if retVal.Elem().Kind() == reflect.Array || retVal.Elem().Kind() == reflect.Slice {
+	if isList(retType) {
 		checkRetLen := retVal.Elem().Kind() == reflect.Array
 		for i := 0; i < len(values) && (!checkRetLen || i < retVal.Elem().Len()); i++ {
 			// the first Elem returns the value of the pointer, the second returns the underlying type of the iterable
@@ -227,7 +369,7 @@ func fromStringsToValue(values []string, fieldType reflect.Type, format string,
 
 			valToSet.Set(v)
 		}
-	} else if retVal.Elem().Kind() == reflect.String {
+	} else if retVal.Elem().Kind() == reflect.String && !HasCodec(retType) {
 		retVal.Elem().Set(reflect.ValueOf(values[0]))
 	} else {
 		v, err := fromStringToValue(values[0], retVal.Elem().Type(), format)
@@ -254,6 +396,10 @@ func fromStringsToValue(values []string, fieldType reflect.Type, format string,
 }
 
 func fromStringToValue(s string, t reflect.Type, format string) (reflect.Value, error) {
+	if c, ok := lookupCodec(t); ok {
+		return c.decode(s)
+	}
+
 	// handle durations first, since it's an alias for int64 and would be picked up by the switch
 	durationType := reflect.TypeOf((*time.Duration)(nil)).Elem()
 	if t == durationType {
@@ -294,6 +440,20 @@ func fromStringToValue(s string, t reflect.Type, format string) (reflect.Value,
 		}
 	}
 
+	if isCalendarType(t) {
+		return calendarFromString(s, t)
+	}
+
+	if t.Kind() == reflect.Interface {
+		if v, ok, err := fromStringToInterface(s, t); ok {
+			return v, err
+		}
+
+		if t.NumMethod() == 0 {
+			return reflect.ValueOf(fromStringToAny(s)), nil
+		}
+	}
+
 	switch t.Kind() {
 	case reflect.String:
 		return reflect.ValueOf(s), nil
@@ -356,7 +516,12 @@ func fromStringToValue(s string, t reflect.Type, format string) (reflect.Value,
 
 	timeType := reflect.TypeOf((*time.Time)(nil)).Elem()
 	if t.AssignableTo(timeType) {
-		ts, err := time.Parse(time.RFC3339, s)
+		layout := time.RFC3339
+		if format != "" {
+			layout = format
+		}
+
+		ts, err := time.Parse(layout, s)
 		return reflect.ValueOf(ts), err
 	}
 